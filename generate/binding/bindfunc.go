@@ -0,0 +1,198 @@
+package binding
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	ginPackage        = protogen.GoImportPath("github.com/gin-gonic/gin")
+	ginBindingPackage = protogen.GoImportPath("github.com/gin-gonic/gin/binding")
+	ioPackage         = protogen.GoImportPath("io")
+	jsonPackage       = protogen.GoImportPath("encoding/json")
+)
+
+// ginBinder emits the statements that map the fields of a location into req
+// with gin, without validating them.
+type ginBinder struct {
+	location string
+	emit     func(g *protogen.GeneratedFile, tagKey string)
+}
+
+// ginBinders lists, in the order they run, the gin binder of each location
+// that has one: the body first, then the URL and the headers. Gin's
+// ShouldBind* methods are not used, as each of them validates the whole
+// struct before the other locations are bound.
+var ginBinders = []ginBinder{
+	{"json", func(g *protogen.GeneratedFile, _ string) {
+		// An empty body, e.g. of a GET request, leaves the JSON fields unset.
+		g.P("if err := ", g.QualifiedGoIdent(jsonPackage.Ident("NewDecoder")), "(ctx.Request.Body).Decode(req); err != nil && !", g.QualifiedGoIdent(errorsPackage.Ident("Is")), "(err, ", g.QualifiedGoIdent(ioPackage.Ident("EOF")), ") {")
+		g.P("return nil, err")
+		g.P("}")
+	}},
+	{"form", func(g *protogen.GeneratedFile, tagKey string) {
+		g.P("if err := ctx.Request.ParseMultipartForm(32 << 20); err != nil && !", g.QualifiedGoIdent(errorsPackage.Ident("Is")), "(err, ", g.QualifiedGoIdent(httpPackage.Ident("ErrNotMultipart")), ") {")
+		g.P("return nil, err")
+		g.P("}")
+		emitMapForm(g, "ctx.Request.PostForm", tagKey)
+	}},
+	{"query", func(g *protogen.GeneratedFile, tagKey string) {
+		emitMapForm(g, "ctx.Request.URL.Query()", tagKey)
+	}},
+	{"uri", func(g *protogen.GeneratedFile, tagKey string) {
+		g.P("params := make(map[string][]string, len(ctx.Params))")
+		g.P("for _, param := range ctx.Params {")
+		g.P("params[param.Key] = []string{param.Value}")
+		g.P("}")
+		emitMapForm(g, "params", tagKey)
+	}},
+	{"header", func(g *protogen.GeneratedFile, tagKey string) {
+		emitMapForm(g, "ctx.Request.Header", tagKey)
+	}},
+}
+
+// emitMapForm emits the mapping of values into the fields of req tagged with
+// tagKey.
+func emitMapForm(g *protogen.GeneratedFile, values, tagKey string) {
+	g.P("if err := ", g.QualifiedGoIdent(ginBindingPackage.Ident("MapFormWithTag")), "(req, ", values, ", ", strconv.Quote(tagKey), "); err != nil {")
+	g.P("return nil, err")
+	g.P("}")
+}
+
+// requestBinding is a request message and the locations its fields bind to.
+type requestBinding struct {
+	message   *protogen.Message
+	locations []string
+}
+
+// GenerateBindFuncs emits <prefix>.binding.go, declaring for every request
+// message of file a Bind<Message>(ctx *gin.Context) function that maps the
+// locations its fields use, and only those, and then validates the message
+// once with gin's binding.Validator. It emits nothing when file has no request
+// message.
+func GenerateBindFuncs(gen *protogen.Plugin, file *protogen.File, config *Config) error {
+	requests, err := requestBindings(file, config)
+	if err != nil {
+		return err
+	}
+	if len(requests) == 0 {
+		return nil
+	}
+
+	g := gen.NewGeneratedFile(file.GeneratedFilenamePrefix+".binding.go", file.GoImportPath)
+	g.P("// Code generated by protoc-gen-sphere-binding. DO NOT EDIT.")
+	g.P("// source: ", file.Desc.Path())
+	g.P()
+	g.P("package ", file.GoPackageName)
+	for _, request := range requests {
+		generateBindFunc(g, request, config)
+	}
+	return nil
+}
+
+func generateBindFunc(g *protogen.GeneratedFile, request requestBinding, config *Config) {
+	name := request.message.GoIdent.GoName
+	var bound, unbound []string
+	for _, binder := range ginBinders {
+		if slices.Contains(request.locations, binder.location) {
+			bound = append(bound, binder.location)
+		}
+	}
+	for _, location := range request.locations {
+		if !slices.Contains(bound, location) {
+			unbound = append(unbound, location)
+		}
+	}
+
+	g.P()
+	if len(bound) > 0 {
+		g.P("// Bind", name, " binds a ", name, " from the ", joinNames(bound), " of the request and validates it.")
+	} else {
+		g.P("// Bind", name, " returns an empty ", name, ".")
+	}
+	if len(unbound) > 0 {
+		g.P("// Its ", joinNames(unbound), " fields have no gin binder and are left to the caller.")
+	}
+	g.P("func Bind", name, "(ctx *", g.QualifiedGoIdent(ginPackage.Ident("Context")), ") (*", name, ", error) {")
+	g.P("req := new(", name, ")")
+	if len(bound) > 0 {
+		// A form body is not JSON.
+		formBody := slices.Contains(bound, "json") && slices.Contains(bound, "form")
+		for _, binder := range ginBinders {
+			if !slices.Contains(bound, binder.location) {
+				continue
+			}
+			if formBody && binder.location == "json" {
+				g.P("if ct := ctx.ContentType(); ct != ", g.QualifiedGoIdent(ginBindingPackage.Ident("MIMEPOSTForm")), " && ct != ", g.QualifiedGoIdent(ginBindingPackage.Ident("MIMEMultipartPOSTForm")), " {")
+				binder.emit(g, "")
+				g.P("}")
+				continue
+			}
+			binder.emit(g, locationTagKey(binder.location, config))
+		}
+		validator := g.QualifiedGoIdent(ginBindingPackage.Ident("Validator"))
+		g.P("if ", validator, " != nil {")
+		g.P("if err := ", validator, ".ValidateStruct(req); err != nil {")
+		g.P("return nil, err")
+		g.P("}")
+		g.P("}")
+	}
+	g.P("return req, nil")
+	g.P("}")
+}
+
+// requestBindings returns the request messages of file with the sorted names
+// of the locations their fields bind to. Request messages are the top-level
+// messages used as the input of a method of the file's services, and those
+// with a field bound outside the JSON body. Messages without fields and oneof
+// members are left out, as gin cannot bind the wrapper structs of the latter.
+func requestBindings(file *protogen.File, config *Config) ([]requestBinding, error) {
+	_, bindings, err := extractFileBindings(file, config)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make(map[protoreflect.FullName]bool)
+	for _, service := range file.Services {
+		for _, method := range service.Methods {
+			inputs[method.Input.Desc.FullName()] = true
+		}
+	}
+	locations := make(map[protoreflect.FullName][]string)
+	for _, b := range bindings {
+		if b.field.Oneof != nil && !b.field.Oneof.Desc.IsSynthetic() {
+			continue
+		}
+		name := b.field.Parent.Desc.FullName()
+		location := config.locationName(b.scope.location)
+		if !slices.Contains(locations[name], location) {
+			locations[name] = append(locations[name], location)
+		}
+	}
+
+	var requests []requestBinding
+	for _, message := range file.Messages {
+		name := message.Desc.FullName()
+		used := locations[name]
+		if len(used) == 0 || !inputs[name] && !slices.ContainsFunc(used, func(location string) bool { return location != "json" }) {
+			continue
+		}
+		slices.Sort(used)
+		requests = append(requests, requestBinding{message: message, locations: used})
+	}
+	return requests, nil
+}
+
+// joinNames joins names for a doc comment, e.g. "query, header and uri".
+func joinNames(locations []string) string {
+	switch len(locations) {
+	case 1:
+		return locations[0]
+	default:
+		return strings.Join(locations[:len(locations)-1], ", ") + " and " + locations[len(locations)-1]
+	}
+}
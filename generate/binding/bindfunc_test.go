@@ -0,0 +1,120 @@
+package binding
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-sphere-binding/generate/internal/testutil"
+)
+
+func TestGenerateBindFuncs(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/custom.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "custom.proto")
+	file := testutil.FileToGenerate(t, plugin)
+
+	cfg := DefaultConfig()
+	cfg.CustomLocations = []CustomLocation{{Name: "grpc_metadata", TagKey: "metadata"}}
+	if err := GenerateBindFuncs(plugin, file, cfg); err != nil {
+		t.Fatal(err)
+	}
+	resp := plugin.Response()
+	if resp.Error != nil {
+		t.Fatal(resp.GetError())
+	}
+	if len(resp.File) != 1 {
+		t.Fatalf("expected one generated file, got %d", len(resp.File))
+	}
+	if got, want := resp.File[0].GetName(), file.GeneratedFilenamePrefix+".binding.go"; got != want {
+		t.Errorf("file name = %q, want %q", got, want)
+	}
+
+	want := `// BindMetadataRequest binds a MetadataRequest from the json and header of the request and validates it.
+// Its cookie and grpc_metadata fields have no gin binder and are left to the caller.
+func BindMetadataRequest(ctx *gin.Context) (*MetadataRequest, error) {
+	req := new(MetadataRequest)
+	if err := json.NewDecoder(ctx.Request.Body).Decode(req); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if err := binding.MapFormWithTag(req, ctx.Request.Header, "header"); err != nil {
+		return nil, err
+	}
+	if binding.Validator != nil {
+		if err := binding.Validator.ValidateStruct(req); err != nil {
+			return nil, err
+		}
+	}
+	return req, nil
+}
+`
+	if content := resp.File[0].GetContent(); !strings.Contains(content, want) {
+		t.Errorf("generated file is missing:\n%s\ngot:\n%s", want, content)
+	}
+}
+
+func TestGenerateBindFuncs_AllLocations(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/basic.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "basic.proto")
+	file := testutil.FileToGenerate(t, plugin)
+	if err := GenerateBindFuncs(plugin, file, DefaultConfig()); err != nil {
+		t.Fatal(err)
+	}
+	content := plugin.Response().File[0].GetContent()
+	for _, want := range []string{
+		// A form body is not decoded as JSON.
+		`	if ct := ctx.ContentType(); ct != binding.MIMEPOSTForm && ct != binding.MIMEMultipartPOSTForm {
+		if err := json.NewDecoder(ctx.Request.Body).Decode(req); err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+	}
+`,
+		`	if err := binding.MapFormWithTag(req, ctx.Request.PostForm, "form"); err != nil {
+`,
+		`	if err := binding.MapFormWithTag(req, ctx.Request.URL.Query(), "query"); err != nil {
+`,
+		`	for _, param := range ctx.Params {
+		params[param.Key] = []string{param.Value}
+	}
+	if err := binding.MapFormWithTag(req, params, "uri"); err != nil {
+`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("generated file is missing:\n%s\ngot:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "ShouldBind") {
+		t.Error("gin ShouldBind methods validate before every location is bound")
+	}
+}
+
+func TestRequestBindings(t *testing.T) {
+	tests := []struct {
+		name string
+		want map[string]string
+	}{
+		{"basic", map[string]string{"BasicRequest": "form,header,json,query,uri"}},
+		{"oneof", map[string]string{"OneofRequest": "query"}},
+		{"no_binding", map[string]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := testutil.LoadDescriptorSet(t, "testdata/pb/"+tt.name+".pb")
+			file := testutil.FileToGenerate(t, testutil.MustCreatePlugin(t, set, tt.name+".proto"))
+			requests, err := requestBindings(file, DefaultConfig())
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make(map[string]string)
+			for _, request := range requests {
+				got[request.message.GoIdent.GoName] = strings.Join(request.locations, ",")
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("requests = %v, want %v", got, tt.want)
+			}
+			for name, locations := range tt.want {
+				if got[name] != locations {
+					t.Errorf("%s locations = %q, want %q", name, got[name], locations)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package binding
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-sphere/binding/sphere/binding"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// bindingLocationNames maps the names accepted by plugin parameters to binding
+// locations.
+var bindingLocationNames = map[string]binding.BindingLocation{
+	"query":  binding.BindingLocation_BINDING_LOCATION_QUERY,
+	"uri":    binding.BindingLocation_BINDING_LOCATION_URI,
+	"json":   binding.BindingLocation_BINDING_LOCATION_JSON,
+	"form":   binding.BindingLocation_BINDING_LOCATION_FORM,
+	"header": binding.BindingLocation_BINDING_LOCATION_HEADER,
+	"file":   BindingLocationFile,
+	"cookie": BindingLocationCookie,
+}
+
+// ParseBindingLocation parses a location name such as "query" (or the full
+// enum name, e.g. "BINDING_LOCATION_QUERY"). The empty string is
+// BINDING_LOCATION_UNSPECIFIED.
+func ParseBindingLocation(name string) (binding.BindingLocation, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return binding.BindingLocation_BINDING_LOCATION_UNSPECIFIED, nil
+	}
+	if location, ok := bindingLocationNames[strings.ToLower(name)]; ok {
+		return location, nil
+	}
+	if value, ok := binding.BindingLocation_value[strings.ToUpper(name)]; ok {
+		return binding.BindingLocation(value), nil
+	}
+	return 0, fmt.Errorf("unknown binding location '%s'", name)
+}
+
+// customLocationBase is the value of the first custom location. Custom
+// locations follow it in the order of Config.CustomLocations, past the
+// upstream enum and the plugin's own locations.
+const customLocationBase binding.BindingLocation = 1000
+
+// CustomLocation maps a location name the upstream BindingLocation enum does
+// not know, e.g. "grpc_metadata", to the tag key fields bound to it get.
+type CustomLocation struct {
+	Name   string
+	TagKey string
+}
+
+// ParseCustomLocations parses comma-separated name:key entries, e.g.
+// "grpc_metadata:metadata". Names are case-insensitive. An entry for a
+// built-in location is accepted only when it repeats the built-in tag key,
+// e.g. "cookie:cookie".
+func ParseCustomLocations(locationStr string) ([]CustomLocation, error) {
+	var locations []CustomLocation
+	seen := make(map[string]bool)
+	for _, entry := range strings.Split(locationStr, ",") {
+		entry = strings.TrimSpace(entry)
+		if len(entry) == 0 {
+			continue
+		}
+
+		name, key, found := strings.Cut(entry, ":")
+		name = strings.ToLower(strings.TrimSpace(name))
+		key = strings.TrimSpace(key)
+		if !found {
+			return nil, fmt.Errorf("invalid custom location format '%s': expected 'name:key'", entry)
+		}
+		if err := ValidateTagKey(name); err != nil {
+			return nil, fmt.Errorf("invalid custom location '%s': %w", entry, err)
+		}
+		if err := ValidateTagKey(key); err != nil {
+			return nil, fmt.Errorf("invalid custom location '%s': %w", entry, err)
+		}
+
+		if builtin, ok := bindingLocationNames[name]; ok {
+			if noJsonBinding[builtin] != key {
+				return nil, fmt.Errorf("invalid custom location '%s': '%s' is a built-in location", entry, name)
+			}
+			continue
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("invalid custom location '%s': '%s' is defined twice", entry, name)
+		}
+		seen[name] = true
+		locations = append(locations, CustomLocation{Name: name, TagKey: key})
+	}
+	return locations, nil
+}
+
+// location resolves a built-in or custom location name.
+func (c *Config) location(name string) (binding.BindingLocation, error) {
+	for i, custom := range c.CustomLocations {
+		if strings.EqualFold(custom.Name, name) {
+			return customLocationBase + binding.BindingLocation(i), nil
+		}
+	}
+	return ParseBindingLocation(name)
+}
+
+// locationTag returns the tag key of a built-in or custom non-JSON location.
+func (c *Config) locationTag(location binding.BindingLocation) (string, bool) {
+	if tag, ok := noJsonBinding[location]; ok {
+		return tag, true
+	}
+	i := int(location - customLocationBase)
+	if location < customLocationBase || i >= len(c.CustomLocations) {
+		return "", false
+	}
+	return c.CustomLocations[i].TagKey, true
+}
+
+// ParseMethodLocations parses comma-separated method=location entries, e.g.
+// "GET=query,DELETE=query". Methods are matched case-insensitively.
+func ParseMethodLocations(methodStr string) (map[string]binding.BindingLocation, error) {
+	locations := make(map[string]binding.BindingLocation)
+	if methodStr == "" {
+		return locations, nil
+	}
+
+	for _, entry := range strings.Split(methodStr, ",") {
+		entry = strings.TrimSpace(entry)
+		if len(entry) == 0 {
+			continue
+		}
+
+		method, name, found := strings.Cut(entry, "=")
+		method = strings.TrimSpace(method)
+		if !found || method == "" {
+			return nil, fmt.Errorf("invalid method location format '%s': expected 'method=location'", entry)
+		}
+		location, err := ParseBindingLocation(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid method location '%s': %w", entry, err)
+		}
+
+		locations[strings.ToUpper(method)] = location
+	}
+
+	return locations, nil
+}
+
+// fileMessageDefaults resolves the file and service level scope of the
+// top-level messages of file, before their own default_location and
+// default_auto_tags options apply. Every message starts from the file-wide
+// Config.DefaultAutoTags. Request messages of the file's service methods also
+// start from Config.DefaultLocation, then use ServiceAutoTags, when set, and
+// the MethodLocations entry of their google.api.http method; a message shared
+// by several methods settles on the most specific location, as with inferred
+// locations.
+func fileMessageDefaults(file *protogen.File, config *Config) (map[protoreflect.FullName]bindingScope, error) {
+	inputs := make(map[protoreflect.FullName]bool)
+	for _, service := range file.Services {
+		for _, method := range service.Methods {
+			inputs[method.Input.Desc.FullName()] = true
+		}
+	}
+
+	defaults := make(map[protoreflect.FullName]bindingScope, len(file.Messages))
+	for _, message := range file.Messages {
+		name := message.Desc.FullName()
+		scope := defaultScope()
+		// Responses and other messages are not bound from requests.
+		if config.DefaultLocation != binding.BindingLocation_BINDING_LOCATION_UNSPECIFIED && inputs[name] {
+			scope = scope.setLocation(config.DefaultLocation, LevelFile, file.Desc.Path(), "default_location")
+		}
+		if len(config.DefaultAutoTags) > 0 {
+			scope = scope.setAutoTags(config.DefaultAutoTags, LevelFile, file.Desc.Path(), "default_auto_tags")
+		}
+		defaults[name] = scope
+	}
+	if len(config.MethodLocations) == 0 && len(config.ServiceAutoTags) == 0 {
+		return defaults, nil
+	}
+
+	methodLocations := make(map[protoreflect.FullName]binding.BindingLocation)
+	for _, service := range file.Services {
+		for _, method := range service.Methods {
+			name := method.Input.Desc.FullName()
+			current, ok := defaults[name]
+			if !ok {
+				// Declared in another file or nested in a message.
+				continue
+			}
+			if len(config.ServiceAutoTags) > 0 {
+				current = current.setAutoTags(config.ServiceAutoTags, LevelService, string(service.Desc.FullName()), "service_auto_tags")
+			}
+
+			rules, err := methodHTTPRules(method)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", method.Desc.FullName(), err)
+			}
+			for _, rule := range rules {
+				location, ok := config.MethodLocations[rule.Method]
+				if !ok {
+					continue
+				}
+				if previous, seen := methodLocations[name]; seen && inferredLocationRank[previous] >= inferredLocationRank[location] {
+					continue
+				}
+				methodLocations[name] = location
+				current = current.setLocation(location, LevelMethod, string(method.Desc.FullName()), "method_locations")
+			}
+			defaults[name] = current
+		}
+	}
+	return defaults, nil
+}
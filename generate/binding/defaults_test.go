@@ -0,0 +1,151 @@
+package binding
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-sphere/binding/sphere/binding"
+)
+
+func TestParseBindingLocation(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    binding.BindingLocation
+		wantErr bool
+	}{
+		{"", binding.BindingLocation_BINDING_LOCATION_UNSPECIFIED, false},
+		{"query", binding.BindingLocation_BINDING_LOCATION_QUERY, false},
+		{"Header", binding.BindingLocation_BINDING_LOCATION_HEADER, false},
+		{"BINDING_LOCATION_URI", binding.BindingLocation_BINDING_LOCATION_URI, false},
+		{"file", BindingLocationFile, false},
+		{"Cookie", BindingLocationCookie, false},
+		{"body", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseBindingLocation(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("ParseBindingLocation(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Fatalf("ParseBindingLocation(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseMethodLocations(t *testing.T) {
+	got, err := ParseMethodLocations("get=query, DELETE=uri")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]binding.BindingLocation{
+		"GET":    binding.BindingLocation_BINDING_LOCATION_QUERY,
+		"DELETE": binding.BindingLocation_BINDING_LOCATION_URI,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ParseMethodLocations = %v, want %v", got, want)
+	}
+	for _, input := range []string{"GET", "=query", "GET=body"} {
+		if _, err := ParseMethodLocations(input); err == nil {
+			t.Errorf("ParseMethodLocations(%q) expected error", input)
+		}
+	}
+}
+
+func TestExtractFile_FileAndServiceDefaults(t *testing.T) {
+	getRule := encodeHTTPRule(httpRuleGet, "/v1/books", "")
+	postRule := encodeHTTPRule(httpRulePost, "/v1/books", "*")
+
+	t.Run("method location applies to request messages", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.MethodLocations = map[string]binding.BindingLocation{"GET": binding.BindingLocation_BINDING_LOCATION_QUERY}
+		tags, err := extractFile(httpTestFile(t, getRule), cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := map[string]map[string]string{
+			"GetBookRequest":      {"Name": `query:"name" json:"-"`, "Shelf": `header:"Shelf" json:"-"`},
+			"GetBookRequest_Book": {"Id": `query:"id" json:"-"`},
+		}
+		for structName, fields := range want {
+			for fieldName, value := range fields {
+				if got := tags[structName][fieldName]; got == nil || got.String() != value {
+					t.Errorf("%s.%s tags = %v, want %q", structName, fieldName, got, value)
+				}
+			}
+		}
+	})
+
+	t.Run("other methods keep message defaults", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.MethodLocations = map[string]binding.BindingLocation{"GET": binding.BindingLocation_BINDING_LOCATION_QUERY}
+		tags, err := extractFile(httpTestFile(t, postRule), cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, ok := tags["GetBookRequest"]["Name"]; ok {
+			t.Fatalf("expected no tags for a POST request, got %q", got)
+		}
+	})
+
+	t.Run("file defaults and service auto tags", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.DefaultLocation = binding.BindingLocation_BINDING_LOCATION_FORM
+		cfg.DefaultAutoTags = []string{"db"}
+		cfg.ServiceAutoTags = []string{"validate"}
+		tags, err := extractFile(httpTestFile(t, postRule), cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := tags["GetBookRequest"]["Page"].String(), `validate:"page" form:"page" json:"-"`; got != want {
+			t.Errorf("GetBookRequest.Page tags = %q, want %q", got, want)
+		}
+		// The file-wide location only applies to request messages.
+		if got, want := tags["GetBookResponse"]["Title"].String(), `db:"title"`; got != want {
+			t.Errorf("GetBookResponse.Title tags = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestParseCustomLocations(t *testing.T) {
+	got, err := ParseCustomLocations("grpc_metadata:metadata, Cookie:cookie,amqp:amqp_header")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []CustomLocation{
+		{Name: "grpc_metadata", TagKey: "metadata"},
+		{Name: "amqp", TagKey: "amqp_header"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ParseCustomLocations = %v, want %v", got, want)
+	}
+	for _, input := range []string{"grpc_metadata", "grpc_metadata:", ":metadata", "cookie:session", "json:json", "a:x,a:y"} {
+		if _, err := ParseCustomLocations(input); err == nil {
+			t.Errorf("ParseCustomLocations(%q) expected error", input)
+		}
+	}
+}
+
+func TestConfigLocation(t *testing.T) {
+	cfg := &Config{CustomLocations: []CustomLocation{
+		{Name: "grpc_metadata", TagKey: "metadata"},
+		{Name: "amqp", TagKey: "amqp_header"},
+	}}
+	for name, wantTag := range map[string]string{"amqp": "amqp_header", "GRPC_METADATA": "metadata", "cookie": "cookie", "query": "query"} {
+		location, err := cfg.location(name)
+		if err != nil {
+			t.Fatalf("location(%q) failed: %v", name, err)
+		}
+		if tag, ok := cfg.locationTag(location); !ok || tag != wantTag {
+			t.Errorf("locationTag(location(%q)) = %q, %v, want %q", name, tag, ok, wantTag)
+		}
+	}
+	if _, err := cfg.location("kafka"); err == nil {
+		t.Error("expected an error for an undefined location")
+	}
+	if _, ok := cfg.locationTag(customLocationBase + 2); ok {
+		t.Error("expected no tag past the custom locations")
+	}
+	if _, ok := cfg.locationTag(binding.BindingLocation_BINDING_LOCATION_JSON); ok {
+		t.Error("expected no tag for the JSON location")
+	}
+}
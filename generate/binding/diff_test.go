@@ -0,0 +1,76 @@
+package binding
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	t.Run("equal input has no diff", func(t *testing.T) {
+		if got := unifiedDiff("a", "b", []byte("x\ny\n"), []byte("x\ny\n")); got != nil {
+			t.Fatalf("unifiedDiff = %q, want nil", got)
+		}
+	})
+
+	t.Run("single changed line", func(t *testing.T) {
+		a := []byte("1\n2\n3\n4\n5\n6\n7\n8\n9\n")
+		b := []byte("1\n2\n3\n4\nfive\n6\n7\n8\n9\n")
+		want := "--- a/f\n+++ b/f\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n"
+		if got := string(unifiedDiff("a/f", "b/f", a, b)); got != want {
+			t.Fatalf("unifiedDiff =\n%s\nwant\n%s", got, want)
+		}
+	})
+
+	t.Run("distant changes get separate hunks", func(t *testing.T) {
+		a := []byte("a\n1\n2\n3\n4\n5\n6\n7\nb\n")
+		b := []byte("A\n1\n2\n3\n4\n5\n6\n7\nB\n")
+		want := "--- x\n+++ y\n" +
+			"@@ -1,4 +1,4 @@\n-a\n+A\n 1\n 2\n 3\n" +
+			"@@ -6,4 +6,4 @@\n 5\n 6\n 7\n-b\n+B\n"
+		if got := string(unifiedDiff("x", "y", a, b)); got != want {
+			t.Fatalf("unifiedDiff =\n%s\nwant\n%s", got, want)
+		}
+	})
+
+	t.Run("insertions and deletions", func(t *testing.T) {
+		a := []byte("a\nb\nc\n")
+		b := []byte("a\nc\nd\n")
+		want := "--- x\n+++ y\n@@ -1,3 +1,3 @@\n a\n-b\n c\n+d\n"
+		if got := string(unifiedDiff("x", "y", a, b)); got != want {
+			t.Fatalf("unifiedDiff =\n%s\nwant\n%s", got, want)
+		}
+	})
+}
+
+func TestDiffLines(t *testing.T) {
+	// Every other line changes, so the edit distance is close to N+M.
+	var a, b []string
+	for i := range 2000 {
+		a = append(a, fmt.Sprint(i))
+		if i%2 == 0 {
+			b = append(b, fmt.Sprint(i))
+		} else {
+			b = append(b, fmt.Sprint("x", i))
+		}
+	}
+	var from, to []string
+	edits := 0
+	for _, op := range diffLines(a, b) {
+		if op.kind != '+' {
+			from = append(from, op.line)
+		}
+		if op.kind != '-' {
+			to = append(to, op.line)
+		}
+		if op.kind != ' ' {
+			edits++
+		}
+	}
+	if !slices.Equal(from, a) || !slices.Equal(to, b) {
+		t.Fatal("edit script does not turn a into b")
+	}
+	if want := 2000; edits != want {
+		t.Errorf("edits = %d, want %d", edits, want)
+	}
+}
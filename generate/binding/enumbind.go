@@ -0,0 +1,104 @@
+package binding
+
+import (
+	"strconv"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// GenerateEnumUnmarshalers emits, for every file of gen to generate, a
+// <prefix>.binding_enum.go declaring an UnmarshalParam method on each enum of
+// the file that a field of any file to generate binds outside the JSON body.
+// The method implements gin's binding.BindUnmarshaler and accepts value names
+// as well as numbers, so that gin binds "STATUS_ACTIVE" from a query string.
+// gin calls it for each element of a repeated enum field from v1.12.0 on;
+// earlier versions only bind numbers into such fields.
+//
+// UnmarshalText is deliberately not generated: encoding/json would then reject
+// the numeric enum values protoc-gen-go structs are marshaled with.
+func GenerateEnumUnmarshalers(gen *protogen.Plugin, config *Config) error {
+	bound, err := boundEnums(gen, config)
+	if err != nil {
+		return err
+	}
+	for _, file := range gen.Files {
+		if !file.Generate {
+			continue
+		}
+		var enums []*protogen.Enum
+		for _, enum := range fileEnums(file) {
+			if bound[enum.Desc.FullName()] {
+				enums = append(enums, enum)
+			}
+		}
+		if len(enums) == 0 {
+			continue
+		}
+
+		g := gen.NewGeneratedFile(file.GeneratedFilenamePrefix+".binding_enum.go", file.GoImportPath)
+		g.P("// Code generated by protoc-gen-sphere-binding. DO NOT EDIT.")
+		g.P("// source: ", file.Desc.Path())
+		g.P()
+		g.P("package ", file.GoPackageName)
+		for _, enum := range enums {
+			generateEnumUnmarshaler(g, enum)
+		}
+	}
+	return nil
+}
+
+// boundEnums returns the enums of the fields of the files to generate that
+// bind outside the JSON body.
+func boundEnums(gen *protogen.Plugin, config *Config) (map[protoreflect.FullName]bool, error) {
+	bound := make(map[protoreflect.FullName]bool)
+	for _, file := range gen.Files {
+		if !file.Generate {
+			continue
+		}
+		_, bindings, err := extractFileBindings(file, config)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range bindings {
+			if b.field.Enum == nil || config.locationName(b.scope.location) == "json" {
+				continue
+			}
+			bound[b.field.Enum.Desc.FullName()] = true
+		}
+	}
+	return bound, nil
+}
+
+// fileEnums returns the enums declared in file, nested ones included.
+func fileEnums(file *protogen.File) []*protogen.Enum {
+	enums := append([]*protogen.Enum(nil), file.Enums...)
+	var walk func([]*protogen.Message)
+	walk = func(messages []*protogen.Message) {
+		for _, message := range messages {
+			enums = append(enums, message.Enums...)
+			walk(message.Messages)
+		}
+	}
+	walk(file.Messages)
+	return enums
+}
+
+func generateEnumUnmarshaler(g *protogen.GeneratedFile, enum *protogen.Enum) {
+	name := enum.GoIdent.GoName
+	g.P()
+	g.P("// UnmarshalParam sets x from the name or number of a ", enum.Desc.Name(), " value, e.g.")
+	g.P("// ", strconv.Quote(string(enum.Values[len(enum.Values)-1].Desc.Name())), ", so that gin binds it from query, form, uri and header values.")
+	g.P("func (x *", name, ") UnmarshalParam(param string) error {")
+	g.P("if n, ok := ", name, "_value[param]; ok {")
+	g.P("*x = ", name, "(n)")
+	g.P("return nil")
+	g.P("}")
+	g.P("n, err := ", g.QualifiedGoIdent(strconvPackage.Ident("ParseInt")), "(param, 10, 32)")
+	g.P("if err != nil {")
+	g.P("return ", g.QualifiedGoIdent(fmtPackage.Ident("Errorf")), "(", strconv.Quote("invalid "+string(enum.Desc.FullName())+" value %q"), ", param)")
+	g.P("}")
+	g.P("*x = ", name, "(n)")
+	g.P("return nil")
+	g.P("}")
+}
@@ -0,0 +1,84 @@
+package binding
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-sphere-binding/generate/internal/testutil"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+func TestGenerateEnumUnmarshalers(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/httpbind.pb")
+
+	t.Run("bound enum", func(t *testing.T) {
+		plugin := testutil.MustCreatePlugin(t, set, "httpbind.proto")
+		file := testutil.FileToGenerate(t, plugin)
+		if err := GenerateEnumUnmarshalers(plugin, DefaultConfig()); err != nil {
+			t.Fatal(err)
+		}
+		resp := plugin.Response()
+		if len(resp.File) != 1 {
+			t.Fatalf("expected one generated file, got %d", len(resp.File))
+		}
+		if got, want := resp.File[0].GetName(), file.GeneratedFilenamePrefix+".binding_enum.go"; got != want {
+			t.Errorf("file name = %q, want %q", got, want)
+		}
+		want := `func (x *Status) UnmarshalParam(param string) error {
+	if n, ok := Status_value[param]; ok {
+		*x = Status(n)
+		return nil
+	}
+	n, err := strconv.ParseInt(param, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid testdata.httpbind.v1.Status value %q", param)
+	}
+	*x = Status(n)
+	return nil
+}
+`
+		if content := resp.File[0].GetContent(); !strings.Contains(content, want) {
+			t.Errorf("generated file is missing:\n%s\ngot:\n%s", want, content)
+		}
+	})
+
+	t.Run("json only", func(t *testing.T) {
+		plugin := testutil.MustCreatePlugin(t, set, "httpbind.proto")
+		cfg := DefaultConfig()
+		cfg.Rules = mustParseRules(t, `
+rules:
+  - match: testdata.httpbind.v1.ListRequest.status*
+    location: json
+`)
+		if err := GenerateEnumUnmarshalers(plugin, cfg); err != nil {
+			t.Fatal(err)
+		}
+		if resp := plugin.Response(); len(resp.File) != 0 {
+			t.Fatalf("expected no generated file, got %d", len(resp.File))
+		}
+	})
+}
+
+func TestGenerateEnumUnmarshalers_Run(t *testing.T) {
+	files := generateGoFiles(t, "httpbind", func(plugin *protogen.Plugin, _ *protogen.File) error {
+		return GenerateEnumUnmarshalers(plugin, DefaultConfig())
+	})
+	files["enum_test.go"] = `package httpbindv1
+
+import "testing"
+
+func TestUnmarshalParam(t *testing.T) {
+	var s Status
+	if err := s.UnmarshalParam("STATUS_ARCHIVED"); err != nil || s != Status_STATUS_ARCHIVED {
+		t.Errorf("UnmarshalParam by name = %v, %v", s, err)
+	}
+	if err := s.UnmarshalParam("1"); err != nil || s != Status_STATUS_ACTIVE {
+		t.Errorf("UnmarshalParam by number = %v, %v", s, err)
+	}
+	if err := s.UnmarshalParam("STATUS_GONE"); err == nil {
+		t.Error("expected an error for an unknown enum value")
+	}
+}
+`
+	testutil.GoTest(t, files)
+}
@@ -0,0 +1,97 @@
+package binding
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// ExplainFile writes to stderr, for every field of file, why it got its tags:
+// the declarations consulted from the file down to the field and what each set,
+// the winning location and auto tags, the aliases applied and whether
+// AutoRemoveJson removed the json tag.
+func ExplainFile(file *protogen.File, config *Config) error {
+	report, err := explainFile(file, config)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprint(os.Stderr, report)
+	return nil
+}
+
+func explainFile(file *protogen.File, config *Config) (string, error) {
+	_, bindings, err := extractFileBindings(file, config)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "protoc-gen-sphere-binding: explain: %s\n", file.Desc.Path())
+	for _, fb := range bindings {
+		explainField(&b, fb, config)
+	}
+	return b.String(), nil
+}
+
+func explainField(b *strings.Builder, fb fieldBinding, config *Config) {
+	scope := fb.scope
+	fmt.Fprintf(b, "  %s (%s.%s)\n", fb.field.Desc.FullName(), fb.structName, fb.field.GoName)
+	for _, step := range scope.trace {
+		fmt.Fprintf(b, "    %s: %s\n", formatSource(step.source), formatStep(step, config))
+	}
+	fmt.Fprintf(b, "    location: %s, from %s\n", config.locationName(scope.location), formatSource(scope.locationSource))
+	autoTags := "none"
+	if len(scope.autoTags) > 0 {
+		autoTags = strings.Join(scope.autoTags, ", ")
+	}
+	fmt.Fprintf(b, "    auto tags: %s, from %s\n", autoTags, formatSource(scope.autoTagsSource))
+
+	options, _ := fb.field.Desc.Options().(*descriptorpb.FieldOptions)
+	tag, bound := config.locationTag(scope.location)
+	switch {
+	case options.GetDeprecated() && config.DeprecatedFields == DeprecatedSkip:
+		fmt.Fprintf(b, "    deprecated: generated tags skipped\n")
+	case !bound:
+		fmt.Fprintf(b, "    auto_remove_json: not applied, the field binds to the JSON body\n")
+	default:
+		if aliases := config.BindingAliases[tag]; len(aliases) > 0 {
+			fmt.Fprintf(b, "    aliases: %s\n", strings.Join(aliases, ", "))
+		}
+		if config.AutoRemoveJson {
+			fmt.Fprintf(b, "    auto_remove_json: json:\"-\" added\n")
+		} else {
+			fmt.Fprintf(b, "    auto_remove_json: disabled, json tag kept\n")
+		}
+	}
+
+	tags := "none"
+	if fb.tags.Len() > 0 {
+		tags = fb.tags.String()
+	}
+	fmt.Fprintf(b, "    tags: %s\n", tags)
+}
+
+func formatSource(source BindingSource) string {
+	if source.Name == "" {
+		return source.Level
+	}
+	return source.Level + " " + source.Name
+}
+
+func formatStep(step scopeStep, config *Config) string {
+	var set string
+	switch {
+	case step.setsLocation:
+		set = "location " + config.locationName(step.location)
+	case step.setsAutoTags:
+		set = "auto tags [" + strings.Join(step.autoTags, ", ") + "]"
+	default:
+		set = "nothing set"
+	}
+	if step.via == "" {
+		return set
+	}
+	return set + " (" + step.via + ")"
+}
@@ -0,0 +1,63 @@
+package binding
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-sphere-binding/generate/internal/testutil"
+)
+
+func TestExplainFile(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/oneof.pb")
+	file := testutil.FileToGenerate(t, testutil.MustCreatePlugin(t, set, "oneof.proto"))
+
+	cfg := DefaultConfig()
+	cfg.BindingAliases = map[string][]string{"query": {"form"}}
+	cfg.Rules = mustParseRules(t, `
+rules:
+  - match: testdata.oneof.v1.OneofRequest.Filter
+    auto_tags: [db]
+  - match: testdata.oneof.v1.OneofRequest.Filter.limit
+    naming: camel
+`)
+	report, err := explainFile(file, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"protoc-gen-sphere-binding: explain: oneof.proto\n",
+		`  testdata.oneof.v1.OneofRequest.by_name (OneofRequest_ByName.ByName)
+    message testdata.oneof.v1.OneofRequest: location query (sphere.binding.default_location)
+    oneof testdata.oneof.v1.OneofRequest.selector: location uri (sphere.binding.default_oneof_location)
+    oneof testdata.oneof.v1.OneofRequest.selector: auto tags [validate] (sphere.binding.default_oneof_auto_tags)
+    field testdata.oneof.v1.OneofRequest.by_name: nothing set
+    location: uri, from oneof testdata.oneof.v1.OneofRequest.selector
+    auto tags: validate, from oneof testdata.oneof.v1.OneofRequest.selector
+    auto_remove_json: json:"-" added
+    tags: validate:"by_name" uri:"by_name" json:"-"
+`,
+		`  testdata.oneof.v1.OneofRequest.Filter.limit (OneofRequest_Filter.Limit)
+    message testdata.oneof.v1.OneofRequest: location query (sphere.binding.default_location)
+    message_rule testdata.oneof.v1.OneofRequest.Filter: auto tags [db] (rules_file)
+    message testdata.oneof.v1.OneofRequest.Filter: nothing set
+    field_rule testdata.oneof.v1.OneofRequest.Filter.limit: nothing set (rules_file)
+    field testdata.oneof.v1.OneofRequest.Filter.limit: nothing set
+    location: query, from message testdata.oneof.v1.OneofRequest
+    auto tags: db, from message_rule testdata.oneof.v1.OneofRequest.Filter
+    aliases: form
+`,
+		`  testdata.oneof.v1.OneofResponse.ok (OneofResponse.Ok)
+    message testdata.oneof.v1.OneofResponse: nothing set
+    field testdata.oneof.v1.OneofResponse.ok: nothing set
+    location: json, from default
+    auto tags: none, from default
+    auto_remove_json: not applied, the field binds to the JSON body
+    tags: none
+`,
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report is missing:\n%s\ngot:\n%s", want, report)
+		}
+	}
+}
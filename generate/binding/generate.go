@@ -1,12 +1,15 @@
 package binding
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 // GenerateFile re-tags the protoc-gen-go output for file, writing the result
@@ -19,10 +22,10 @@ func GenerateFile(file *protogen.File, out string, config *Config) error {
 // generateFile orchestrates the impure steps: extract tags from the descriptor,
 // resolve the target path, read the existing .pb.go, apply the tags, and write
 // it back atomically. All of the logic that does not touch the filesystem lives
-// in the pure helpers (extractFile, resolveOutputPath, RetagSource) so it can be
+// in the pure helpers (rewriteTags, resolveOutputPath, RetagSource) so it can be
 // unit tested in isolation.
 func generateFile(file *protogen.File, out string, config *Config) error {
-	tags, err := extractFile(file, config)
+	tags, tagged, err := rewriteTags(file, config)
 	if err != nil {
 		return err
 	}
@@ -38,6 +41,10 @@ func generateFile(file *protogen.File, out string, config *Config) error {
 	// Preserve original file permissions.
 	originalInfo, err := os.Stat(filename)
 	if err != nil {
+		if !tagged && errors.Is(err, fs.ErrNotExist) {
+			// Only pruning is left to do, and a missing file has nothing to prune.
+			return nil
+		}
 		return err
 	}
 	originalPerm := originalInfo.Mode().Perm()
@@ -47,17 +54,155 @@ func generateFile(file *protogen.File, out string, config *Config) error {
 		return err
 	}
 
-	source, changed, err := RetagSource(filename, src, tags)
+	source, report, err := RetagSourceReport(filename, src, tags)
 	if err != nil {
 		return err
 	}
-	if !changed {
+	if err = reportUnapplied(file, filename, report.Unapplied, config); err != nil {
+		return err
+	}
+	if len(report.Changes) == 0 {
 		return nil
 	}
 
 	return writeFileAtomic(filename, source, originalPerm)
 }
 
+// CheckFile reports the struct fields of the .pb.go file for file under out
+// whose binding tags are missing or stale, without modifying anything on disk.
+// A missing .pb.go file is an error.
+func CheckFile(file *protogen.File, out string, config *Config) ([]TagChange, error) {
+	tags, _, err := rewriteTags(file, config)
+	if err != nil {
+		return nil, err
+	}
+
+	filename, err := resolveOutputPath(out, file.GeneratedFilenamePrefix)
+	if err != nil {
+		return nil, err
+	}
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	report, err := PlanRetag(filename, src, tags)
+	if err != nil {
+		return nil, err
+	}
+	if err = reportUnapplied(file, filename, report.Unapplied, config); err != nil {
+		return nil, err
+	}
+	return report.Changes, nil
+}
+
+// GenerateDiff computes the re-tagging generateFile would apply to the .pb.go
+// file for file under out and emits it as a unified diff in
+// <prefix>.binding.diff instead of rewriting the file. Nothing is emitted when
+// the tags are already up to date.
+func GenerateDiff(gen *protogen.Plugin, file *protogen.File, out string, config *Config) error {
+	tags, tagged, err := rewriteTags(file, config)
+	if err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	filename, err := resolveOutputPath(out, file.GeneratedFilenamePrefix)
+	if err != nil {
+		return err
+	}
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		if !tagged && errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	source, report, err := RetagSourceReport(filename, src, tags)
+	if err != nil {
+		return err
+	}
+	if err = reportUnapplied(file, filename, report.Unapplied, config); err != nil {
+		return err
+	}
+	if len(report.Changes) == 0 {
+		return nil
+	}
+
+	name := file.GeneratedFilenamePrefix + ".pb.go"
+	diff := unifiedDiff("a/"+name, "b/"+name, src, source)
+	_, err = gen.NewGeneratedFile(file.GeneratedFilenamePrefix+".binding.diff", "").Write(diff)
+	return err
+}
+
+// reportUnapplied handles tags that matched no struct field in the generated
+// file filename. In strict mode they are an error pointing at the proto
+// declarations they came from; otherwise they are reported as warnings on
+// stderr, since protoc shows plugin stderr to the user.
+func reportUnapplied(file *protogen.File, filename string, unapplied []UnappliedTag, config *Config) error {
+	if len(unapplied) == 0 {
+		return nil
+	}
+	lines := make([]string, 0, len(unapplied))
+	for _, u := range unapplied {
+		lines = append(lines, fmt.Sprintf("%s: %s in %s", sourcePosition(file, u), u, filename))
+	}
+	if config.Strict {
+		return fmt.Errorf("binding tags could not be applied:\n  %s", strings.Join(lines, "\n  "))
+	}
+	for _, line := range lines {
+		_, _ = fmt.Fprintf(os.Stderr, "protoc-gen-sphere-binding: warning: %s\n", line)
+	}
+	return nil
+}
+
+// sourcePosition returns the proto source position ("file.proto:line:col") of
+// the message or field whose tags are described by u, falling back to the
+// proto file path when the descriptor or its source info cannot be found.
+func sourcePosition(file *protogen.File, u UnappliedTag) string {
+	desc := findGoDescriptor(file.Messages, u.Struct, u.Field)
+	if desc == nil {
+		return file.Desc.Path()
+	}
+	return descriptorPosition(file, desc)
+}
+
+// descriptorPosition returns the proto source position of desc in file,
+// falling back to the proto file path without source info.
+func descriptorPosition(file *protogen.File, desc protoreflect.Descriptor) string {
+	loc := file.Desc.SourceLocations().ByDescriptor(desc)
+	if loc.StartLine == 0 && loc.StartColumn == 0 && loc.EndLine == 0 {
+		return file.Desc.Path()
+	}
+	return fmt.Sprintf("%s:%d:%d", file.Desc.Path(), loc.StartLine+1, loc.StartColumn+1)
+}
+
+// findGoDescriptor maps a generated Go struct, and optionally one of its
+// fields, back to the proto descriptor protoc-gen-go emitted it for. Oneof
+// members are matched by their wrapper struct.
+func findGoDescriptor(messages []*protogen.Message, structName, fieldName string) protoreflect.Descriptor {
+	for _, message := range messages {
+		if fieldName == "" && message.GoIdent.GoName == structName {
+			return message.Desc
+		}
+		for _, field := range message.Fields {
+			owner := message.GoIdent.GoName
+			if field.Oneof != nil && !field.Oneof.Desc.IsSynthetic() {
+				owner = field.GoIdent.GoName
+			}
+			if owner == structName && (fieldName == "" || field.GoName == fieldName) {
+				return field.Desc
+			}
+		}
+		if desc := findGoDescriptor(message.Messages, structName, fieldName); desc != nil {
+			return desc
+		}
+	}
+	return nil
+}
+
 // resolveOutputPath builds the target .pb.go path for prefix inside out and
 // guards against path traversal escaping the output directory. It performs no
 // I/O, which makes it cheap to unit test.
@@ -3,6 +3,7 @@ package binding
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/go-sphere/protoc-gen-sphere-binding/generate/internal/testutil"
@@ -100,3 +101,156 @@ func TestGenerateFile_NoOptions(t *testing.T) {
 		t.Error("expected the file to be left untouched when there are no binding options")
 	}
 }
+
+// TestGenerateFile_NoOptionsMissingFile verifies that a descriptor without any
+// binding options never needs its .pb.go file, even though pruning is on.
+func TestGenerateFile_NoOptionsMissingFile(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/no_binding.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "no_binding.proto")
+	file := testutil.FileToGenerate(t, plugin)
+
+	if err := GenerateFile(file, t.TempDir(), DefaultConfig()); err != nil {
+		t.Fatalf("GenerateFile failed: %v", err)
+	}
+	if err := GenerateDiff(plugin, file, t.TempDir(), DefaultConfig()); err != nil {
+		t.Fatalf("GenerateDiff failed: %v", err)
+	}
+}
+
+// TestCheckFile verifies check mode: raw protoc-gen-go output is reported as
+// stale field by field, the golden output is up to date, and a missing file is
+// an error. The file on disk is never modified.
+func TestCheckFile(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/basic.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "basic.proto")
+	file := testutil.FileToGenerate(t, plugin)
+
+	layout := func(t *testing.T, fixture string) (string, []byte) {
+		t.Helper()
+		input, err := os.ReadFile(fixture)
+		if err != nil {
+			t.Fatalf("read fixture %q: %v", fixture, err)
+		}
+		out := t.TempDir()
+		dst := filepath.Join(out, file.GeneratedFilenamePrefix+".pb.go")
+		if err = os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err = os.WriteFile(dst, input, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return out, input
+	}
+
+	t.Run("stale tags are reported", func(t *testing.T) {
+		out, input := layout(t, "testdata/gen/basic.pb.go")
+		changes, err := CheckFile(file, out, DefaultConfig())
+		if err != nil {
+			t.Fatalf("CheckFile failed: %v", err)
+		}
+		if len(changes) != 5 {
+			t.Fatalf("expected 5 stale fields, got %d: %v", len(changes), changes)
+		}
+		if got := changes[0]; got.Struct != "BasicRequest" || got.Field != "PathId" {
+			t.Errorf("first change = %s.%s, want BasicRequest.PathId", got.Struct, got.Field)
+		}
+		got, err := os.ReadFile(filepath.Join(out, file.GeneratedFilenamePrefix+".pb.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != string(input) {
+			t.Error("CheckFile must not modify the file")
+		}
+	})
+
+	t.Run("golden output is up to date", func(t *testing.T) {
+		out, _ := layout(t, "testdata/golden/basic.pb.go")
+		changes, err := CheckFile(file, out, DefaultConfig())
+		if err != nil {
+			t.Fatalf("CheckFile failed: %v", err)
+		}
+		if len(changes) != 0 {
+			t.Fatalf("expected no stale fields, got %v", changes)
+		}
+	})
+
+	t.Run("missing file is an error", func(t *testing.T) {
+		if _, err := CheckFile(file, t.TempDir(), DefaultConfig()); err == nil {
+			t.Fatal("expected an error for a missing .pb.go file")
+		}
+	})
+}
+
+// TestGenerateDiff verifies dry-run mode: the planned retagging is emitted as a
+// <prefix>.binding.diff file and the .pb.go file on disk stays untouched.
+func TestGenerateDiff(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/basic.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "basic.proto")
+	file := testutil.FileToGenerate(t, plugin)
+
+	input, err := os.ReadFile("testdata/gen/basic.pb.go")
+	if err != nil {
+		t.Fatalf("read input fixture (run `make testdata`): %v", err)
+	}
+	out := t.TempDir()
+	dst := filepath.Join(out, file.GeneratedFilenamePrefix+".pb.go")
+	if err = os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(dst, input, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = GenerateDiff(plugin, file, out, DefaultConfig()); err != nil {
+		t.Fatalf("GenerateDiff failed: %v", err)
+	}
+	resp := plugin.Response()
+	if len(resp.File) != 1 {
+		t.Fatalf("expected one generated file, got %d", len(resp.File))
+	}
+	if got, want := resp.File[0].GetName(), file.GeneratedFilenamePrefix+".binding.diff"; got != want {
+		t.Errorf("diff file name = %q, want %q", got, want)
+	}
+	diff := resp.File[0].GetContent()
+	for _, line := range []string{
+		"--- a/" + file.GeneratedFilenamePrefix + ".pb.go",
+		"+\tPathId      string                 `protobuf:\"bytes,1,opt,name=path_id,json=pathId,proto3\" json:\"-\" uri:\"path_id\"`",
+	} {
+		if !strings.Contains(diff, line+"\n") {
+			t.Errorf("diff is missing line %q:\n%s", line, diff)
+		}
+	}
+
+	got, err := os.ReadFile(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(input) {
+		t.Error("GenerateDiff must not modify the file")
+	}
+}
+
+// TestReportUnapplied verifies that strict mode turns a tag without a matching
+// generated field into an error that points at the proto declaration, while the
+// default mode only warns.
+func TestReportUnapplied(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/basic.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "basic.proto")
+	file := testutil.FileToGenerate(t, plugin)
+
+	unapplied := []UnappliedTag{{Struct: "BasicRequest", Field: "Page"}}
+
+	if err := reportUnapplied(file, "basic.pb.go", unapplied, DefaultConfig()); err != nil {
+		t.Fatalf("non-strict mode must not fail: %v", err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.Strict = true
+	err := reportUnapplied(file, "basic.pb.go", unapplied, cfg)
+	if err == nil {
+		t.Fatal("expected an error in strict mode")
+	}
+	if want := "basic.proto:20:3: field BasicRequest.Page not found in basic.pb.go"; !strings.Contains(err.Error(), want) {
+		t.Fatalf("error = %q, want it to contain %q", err, want)
+	}
+}
@@ -53,6 +53,43 @@ func goldenCases() []goldenCase {
 				}
 			},
 		},
+		{
+			// Same proto as basic, with camelCase tag values by default and an
+			// X- prefix on the canonical header names.
+			name:       "basic_naming",
+			pbFile:     "testdata/pb/basic.pb",
+			protoName:  "basic.proto",
+			inputFile:  "testdata/gen/basic.pb.go",
+			wantChange: true,
+			goldenFile: "testdata/golden/basic_naming.pb.go",
+			config: func() *Config {
+				cfg := DefaultConfig()
+				cfg.TagNaming = map[string]NamingStrategy{"": NamingCamel}
+				cfg.HeaderPrefix = "X-"
+				return cfg
+			},
+		},
+		{
+			// Re-running the basic_aliases configuration against the output of
+			// basic restores the protoc-gen-go json tags and converges to the
+			// basic_aliases golden file.
+			name:       "basic_aliases_rerun",
+			pbFile:     "testdata/pb/basic.pb",
+			protoName:  "basic.proto",
+			inputFile:  "testdata/golden/basic.pb.go",
+			wantChange: true,
+			goldenFile: "testdata/golden/basic_aliases.pb.go",
+			config: func() *Config {
+				return &Config{
+					AutoRemoveJson: false,
+					BindingAliases: map[string][]string{
+						"query": {"form"},
+						"uri":   {"path"},
+					},
+					PruneTags: true,
+				}
+			},
+		},
 		{
 			name:       "tags",
 			pbFile:     "testdata/pb/tags.pb",
@@ -69,6 +106,160 @@ func goldenCases() []goldenCase {
 			wantChange: true,
 			goldenFile: "testdata/golden/oneof.pb.go",
 		},
+		{
+			// proto3 optional fields get omitempty, and the configured query
+			// options add it to non-optional query fields without repeating it.
+			name:       "options",
+			pbFile:     "testdata/pb/options.pb",
+			protoName:  "options.proto",
+			inputFile:  "testdata/gen/options.pb.go",
+			wantChange: true,
+			goldenFile: "testdata/golden/options.pb.go",
+			config: func() *Config {
+				cfg := DefaultConfig()
+				cfg.OptionalOmitEmpty = true
+				cfg.TagOptions = map[string][]string{"query": {"omitempty"}}
+				return cfg
+			},
+		},
+		{
+			// Explicit proto2 defaults become default= options out of the box.
+			name:       "defaults",
+			pbFile:     "testdata/pb/defaults.pb",
+			protoName:  "defaults.proto",
+			inputFile:  "testdata/gen/defaults.pb.go",
+			wantChange: true,
+			goldenFile: "testdata/golden/defaults.pb.go",
+		},
+		{
+			// Deprecated fields are tagged like any other field by default.
+			name:       "field_options",
+			pbFile:     "testdata/pb/field_options.pb",
+			protoName:  "field_options.proto",
+			inputFile:  "testdata/gen/field_options.pb.go",
+			wantChange: true,
+			goldenFile: "testdata/golden/field_options.pb.go",
+		},
+		{
+			// The json naming strategy picks up the custom json_name, and
+			// deprecated fields get a deprecated:"true" marker.
+			name:       "field_options_json_mark",
+			pbFile:     "testdata/pb/field_options.pb",
+			protoName:  "field_options.proto",
+			inputFile:  "testdata/gen/field_options.pb.go",
+			wantChange: true,
+			goldenFile: "testdata/golden/field_options_json_mark.pb.go",
+			config: func() *Config {
+				cfg := DefaultConfig()
+				cfg.TagNaming = map[string]NamingStrategy{"": NamingJSON}
+				cfg.DeprecatedFields = DeprecatedMark
+				return cfg
+			},
+		},
+		{
+			// Deprecated fields keep only their manual tags.
+			name:       "field_options_skip",
+			pbFile:     "testdata/pb/field_options.pb",
+			protoName:  "field_options.proto",
+			inputFile:  "testdata/gen/field_options.pb.go",
+			wantChange: true,
+			goldenFile: "testdata/golden/field_options_skip.pb.go",
+			config: func() *Config {
+				cfg := DefaultConfig()
+				cfg.DeprecatedFields = DeprecatedSkip
+				return cfg
+			},
+		},
+		{
+			// The same unannotated proto as no_binding, tagged by a rules file.
+			name:       "no_binding_rules",
+			pbFile:     "testdata/pb/no_binding.pb",
+			protoName:  "no_binding.proto",
+			inputFile:  "testdata/gen/no_binding.pb.go",
+			wantChange: true,
+			goldenFile: "testdata/golden/no_binding_rules.pb.go",
+			config: func() *Config {
+				cfg := DefaultConfig()
+				cfg.Rules = mustLoadRules("testdata/rules/no_binding.yaml")
+				return cfg
+			},
+		},
+		{
+			// Rules remove db from the oneof field and the
+			// oneof level validate tag from its members.
+			name:       "oneof_remove",
+			pbFile:     "testdata/pb/oneof.pb",
+			protoName:  "oneof.proto",
+			inputFile:  "testdata/gen/oneof.pb.go",
+			wantChange: true,
+			goldenFile: "testdata/golden/oneof_remove.pb.go",
+			config: func() *Config {
+				cfg := DefaultConfig()
+				cfg.Rules = mustLoadRules("testdata/rules/oneof_remove.yaml")
+				return cfg
+			},
+		},
+		{
+			// A rules file moves the bytes and message fields to the file
+			// location, which adds the file marker next to the form tag.
+			name:       "upload",
+			pbFile:     "testdata/pb/upload.pb",
+			protoName:  "upload.proto",
+			inputFile:  "testdata/gen/upload.pb.go",
+			wantChange: true,
+			goldenFile: "testdata/golden/upload.pb.go",
+			config: func() *Config {
+				cfg := DefaultConfig()
+				cfg.Rules = mustLoadRules("testdata/rules/upload.yaml")
+				return cfg
+			},
+		},
+		{
+			// A rules file moves the session fields to the cookie location.
+			name:       "cookie",
+			pbFile:     "testdata/pb/cookie.pb",
+			protoName:  "cookie.proto",
+			inputFile:  "testdata/gen/cookie.pb.go",
+			wantChange: true,
+			goldenFile: "testdata/golden/cookie.pb.go",
+			config: func() *Config {
+				cfg := DefaultConfig()
+				cfg.Rules = mustLoadRules("testdata/rules/cookie.yaml")
+				return cfg
+			},
+		},
+		{
+			// Same as cookie with the json tags kept and a session alias for
+			// the cookie tag.
+			name:       "cookie_aliases",
+			pbFile:     "testdata/pb/cookie.pb",
+			protoName:  "cookie.proto",
+			inputFile:  "testdata/gen/cookie.pb.go",
+			wantChange: true,
+			goldenFile: "testdata/golden/cookie_aliases.pb.go",
+			config: func() *Config {
+				return &Config{
+					AutoRemoveJson: false,
+					BindingAliases: map[string][]string{"cookie": {"session"}},
+					Rules:          mustLoadRules("testdata/rules/cookie.yaml"),
+				}
+			},
+		},
+		{
+			// Location directives select a custom location defined by the
+			// config and the built-in cookie and header locations.
+			name:       "custom_locations",
+			pbFile:     "testdata/pb/custom.pb",
+			protoName:  "custom.proto",
+			inputFile:  "testdata/gen/custom.pb.go",
+			wantChange: true,
+			goldenFile: "testdata/golden/custom_locations.pb.go",
+			config: func() *Config {
+				cfg := DefaultConfig()
+				cfg.CustomLocations = []CustomLocation{{Name: "grpc_metadata", TagKey: "metadata"}}
+				return cfg
+			},
+		},
 		{
 			// No sphere.binding options, so the plugin must leave the file alone.
 			name:       "no_binding",
@@ -95,9 +286,9 @@ func (tt goldenCase) generate(t *testing.T) ([]byte, bool) {
 		cfg = tt.config()
 	}
 
-	tags, err := extractFile(file, cfg)
+	tags, _, err := rewriteTags(file, cfg)
 	if err != nil {
-		t.Fatalf("extractFile(%s) failed: %v", tt.name, err)
+		t.Fatalf("rewriteTags(%s) failed: %v", tt.name, err)
 	}
 
 	src, err := os.ReadFile(tt.inputFile)
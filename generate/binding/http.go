@@ -0,0 +1,314 @@
+package binding
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-sphere/binding/sphere/binding"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// httpExtensionNumber is the field number of the google.api.http extension on
+// google.protobuf.MethodOptions. The plugin decodes the HttpRule by hand so it
+// does not have to link the googleapis annotations package.
+const httpExtensionNumber protowire.Number = 72295728
+
+// google.api.HttpRule field numbers.
+const (
+	httpRuleGet                protowire.Number = 2
+	httpRulePut                protowire.Number = 3
+	httpRulePost               protowire.Number = 4
+	httpRuleDelete             protowire.Number = 5
+	httpRulePatch              protowire.Number = 6
+	httpRuleBody               protowire.Number = 7
+	httpRuleCustom             protowire.Number = 8
+	httpRuleAdditionalBindings protowire.Number = 11
+
+	customHttpPatternKind protowire.Number = 1
+	customHttpPatternPath protowire.Number = 2
+)
+
+// httpRule is the subset of google.api.HttpRule the plugin needs to infer
+// binding locations.
+type httpRule struct {
+	Method string
+	Path   string
+	Body   string
+
+	AdditionalBindings []*httpRule
+}
+
+// fieldLocations maps a proto field to the binding location inferred for it
+// from the service definitions. Explicit field locations always win, and
+// message and oneof locations win over all but path variables.
+type fieldLocations map[protoreflect.FullName]binding.BindingLocation
+
+// inferredLocationRank orders inferred locations so that a field used by
+// several methods or bindings settles on the most specific one: a path
+// variable beats the body, which beats the query string.
+var inferredLocationRank = map[binding.BindingLocation]int{
+	binding.BindingLocation_BINDING_LOCATION_QUERY: 1,
+	binding.BindingLocation_BINDING_LOCATION_JSON:  2,
+	binding.BindingLocation_BINDING_LOCATION_URI:   3,
+}
+
+// apply returns scope with the location inferred for field, if any. A path
+// variable always binds to uri. The body and query split of
+// infer_http_locations ranks below the default_location and
+// default_oneof_location options, including those of an enclosing message, and
+// is not applied when one of them set the location.
+func (l fieldLocations) apply(field *protogen.Field, scope bindingScope) bindingScope {
+	location, ok := l[field.Desc.FullName()]
+	if !ok {
+		return scope
+	}
+	if location != binding.BindingLocation_BINDING_LOCATION_URI && scope.locationSetAt(LevelMessage, LevelOneof) {
+		return scope
+	}
+	return scope.setLocation(location, LevelInferred, string(field.Desc.FullName()), "google.api.http")
+}
+
+// set records location for field unless a higher ranked location was already
+// inferred for it.
+func (l fieldLocations) set(field *protogen.Field, location binding.BindingLocation) {
+	name := field.Desc.FullName()
+	if current, ok := l[name]; ok && inferredLocationRank[current] >= inferredLocationRank[location] {
+		return
+	}
+	l[name] = location
+}
+
+// inferFileLocations walks every service method in file and in
+// Config.ServiceFiles and collects the binding locations implied by its
+// google.api.http rule. It returns nil when inference is disabled.
+func inferFileLocations(file *protogen.File, config *Config) (fieldLocations, error) {
+	if !config.InferURILocations && !config.InferHTTPLocations {
+		return nil, nil
+	}
+	var services []*protogen.Service
+	services = append(services, file.Services...)
+	for _, f := range config.ServiceFiles {
+		if f != file {
+			services = append(services, f.Services...)
+		}
+	}
+	locations := make(fieldLocations)
+	for _, service := range services {
+		for _, method := range service.Methods {
+			rules, err := methodHTTPRules(method)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", method.Desc.FullName(), err)
+			}
+			for _, rule := range rules {
+				if err = inferRuleLocations(method, rule, locations, config.InferHTTPLocations); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return locations, nil
+}
+
+// inferRuleLocations marks every field referenced by a path template variable of
+// rule as a URI binding. When withBody is set, the remaining top-level request
+// fields are split between the JSON body (the field named by rule.Body, or all
+// of them for "*") and the query string.
+func inferRuleLocations(method *protogen.Method, rule *httpRule, locations fieldLocations, withBody bool) error {
+	variables, err := parsePathVariables(rule.Path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", method.Desc.FullName(), err)
+	}
+	pathRoots := make(map[string]bool, len(variables))
+	for _, variable := range variables {
+		field, fErr := resolveFieldPath(method.Input, variable)
+		if fErr != nil {
+			return fmt.Errorf("%s: path variable %q in %q: %w", method.Desc.FullName(), variable, rule.Path, fErr)
+		}
+		locations.set(field, binding.BindingLocation_BINDING_LOCATION_URI)
+		pathRoots[strings.SplitN(variable, ".", 2)[0]] = true
+	}
+	if !withBody {
+		return nil
+	}
+
+	if rule.Body != "" && rule.Body != "*" {
+		if strings.Contains(rule.Body, ".") {
+			return fmt.Errorf("%s: body %q must name a top-level field", method.Desc.FullName(), rule.Body)
+		}
+		if _, fErr := resolveFieldPath(method.Input, rule.Body); fErr != nil {
+			return fmt.Errorf("%s: body %q: %w", method.Desc.FullName(), rule.Body, fErr)
+		}
+	}
+	for _, field := range method.Input.Fields {
+		name := string(field.Desc.Name())
+		if pathRoots[name] {
+			continue
+		}
+		if rule.Body == "*" || rule.Body == name {
+			locations.set(field, binding.BindingLocation_BINDING_LOCATION_JSON)
+		} else {
+			locations.set(field, binding.BindingLocation_BINDING_LOCATION_QUERY)
+		}
+	}
+	return nil
+}
+
+// resolveFieldPath resolves a dotted field path such as "user.id" against
+// message and returns the leaf field.
+func resolveFieldPath(message *protogen.Message, path string) (*protogen.Field, error) {
+	segments := strings.Split(path, ".")
+	current := message
+	for i, segment := range segments {
+		var found *protogen.Field
+		for _, field := range current.Fields {
+			if string(field.Desc.Name()) == segment {
+				found = field
+				break
+			}
+		}
+		if found == nil {
+			return nil, fmt.Errorf("no field %q in message %s", segment, current.Desc.FullName())
+		}
+		if i == len(segments)-1 {
+			return found, nil
+		}
+		if found.Message == nil || found.Desc.IsList() || found.Desc.IsMap() {
+			return nil, fmt.Errorf("field %q in message %s is not a singular message", segment, current.Desc.FullName())
+		}
+		current = found.Message
+	}
+	return nil, fmt.Errorf("empty field path")
+}
+
+// parsePathVariables returns the field paths of the variables in an HTTP path
+// template, e.g. "/v1/{name=shelves/*}/books/{book.id}" yields
+// ["name", "book.id"].
+func parsePathVariables(template string) ([]string, error) {
+	var variables []string
+	for rest := template; ; {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated variable in path template %q", template)
+		}
+		variable := rest[start+1 : start+end]
+		if eq := strings.IndexByte(variable, '='); eq >= 0 {
+			variable = variable[:eq]
+		}
+		variable = strings.TrimSpace(variable)
+		if variable == "" {
+			return nil, fmt.Errorf("empty variable in path template %q", template)
+		}
+		variables = append(variables, variable)
+		rest = rest[start+end+1:]
+	}
+	return variables, nil
+}
+
+// methodHTTPRules returns the google.api.http rule of method followed by its
+// additional bindings, or nil when the method has no rule.
+func methodHTTPRules(method *protogen.Method) ([]*httpRule, error) {
+	options := method.Desc.Options()
+	if options == nil {
+		return nil, nil
+	}
+	raw, err := proto.MarshalOptions{Deterministic: true}.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []*httpRule
+	err = rangeBytesFields(raw, func(num protowire.Number, value []byte) error {
+		if num != httpExtensionNumber {
+			return nil
+		}
+		rule, rErr := parseHTTPRule(value)
+		if rErr != nil {
+			return rErr
+		}
+		rules = append(rules, rule)
+		rules = append(rules, rule.AdditionalBindings...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// parseHTTPRule decodes a serialized google.api.HttpRule.
+func parseHTTPRule(raw []byte) (*httpRule, error) {
+	rule := &httpRule{}
+	err := rangeBytesFields(raw, func(num protowire.Number, value []byte) error {
+		switch num {
+		case httpRuleGet:
+			rule.Method, rule.Path = "GET", string(value)
+		case httpRulePut:
+			rule.Method, rule.Path = "PUT", string(value)
+		case httpRulePost:
+			rule.Method, rule.Path = "POST", string(value)
+		case httpRuleDelete:
+			rule.Method, rule.Path = "DELETE", string(value)
+		case httpRulePatch:
+			rule.Method, rule.Path = "PATCH", string(value)
+		case httpRuleBody:
+			rule.Body = string(value)
+		case httpRuleCustom:
+			return rangeBytesFields(value, func(num protowire.Number, value []byte) error {
+				switch num {
+				case customHttpPatternKind:
+					rule.Method = strings.ToUpper(string(value))
+				case customHttpPatternPath:
+					rule.Path = string(value)
+				}
+				return nil
+			})
+		case httpRuleAdditionalBindings:
+			additional, err := parseHTTPRule(value)
+			if err != nil {
+				return err
+			}
+			rule.AdditionalBindings = append(rule.AdditionalBindings, additional)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// rangeBytesFields calls fn for every length-delimited field in the serialized
+// message raw, skipping fields of any other wire type.
+func rangeBytesFields(raw []byte, fn func(num protowire.Number, value []byte) error) error {
+	for len(raw) > 0 {
+		num, typ, n := protowire.ConsumeTag(raw)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		raw = raw[n:]
+		if typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, raw)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			raw = raw[n:]
+			continue
+		}
+		value, m := protowire.ConsumeBytes(raw)
+		if m < 0 {
+			return protowire.ParseError(m)
+		}
+		raw = raw[m:]
+		if err := fn(num, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,402 @@
+package binding
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-sphere/binding/sphere/binding"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// encodeHTTPRule serializes a google.api.HttpRule with a single pattern field
+// and an optional body, mirroring what protoc stores in the method options.
+func encodeHTTPRule(pattern protowire.Number, path, body string) []byte {
+	var b []byte
+	b = protowire.AppendTag(b, pattern, protowire.BytesType)
+	b = protowire.AppendString(b, path)
+	if body != "" {
+		b = protowire.AppendTag(b, httpRuleBody, protowire.BytesType)
+		b = protowire.AppendString(b, body)
+	}
+	return b
+}
+
+// httpMethodOptions returns MethodOptions carrying rule as the google.api.http
+// extension. The extension is stored as unknown fields, exactly as protogen
+// sees it when the googleapis annotations package is not linked.
+func httpMethodOptions(rule []byte) *descriptorpb.MethodOptions {
+	var raw []byte
+	raw = protowire.AppendTag(raw, httpExtensionNumber, protowire.BytesType)
+	raw = protowire.AppendBytes(raw, rule)
+	opts := &descriptorpb.MethodOptions{}
+	opts.ProtoReflect().SetUnknown(raw)
+	return opts
+}
+
+func stringField(name string, number int32, opts *descriptorpb.FieldOptions) *descriptorpb.FieldDescriptorProto {
+	return &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		Number:   proto.Int32(number),
+		Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		JsonName: proto.String(name),
+		Options:  opts,
+	}
+}
+
+// httpTestFile builds a single-file plugin whose GetBook method is routed by
+// rule. GetBookRequest has a plain "name" field, an explicitly annotated
+// "shelf" field, a nested "book" message with an "id" field and a plain "page"
+// field. GetBookResponse has a plain "title" field.
+func httpTestFile(t *testing.T, rule []byte) *protogen.File {
+	t.Helper()
+	return httpTestFileWithOptions(t, rule, nil)
+}
+
+// httpTestFileWithOptions is httpTestFile with requestOpts set as the options
+// of GetBookRequest.
+func httpTestFileWithOptions(t *testing.T, rule []byte, requestOpts *descriptorpb.MessageOptions) *protogen.File {
+	t.Helper()
+
+	shelfOpts := &descriptorpb.FieldOptions{}
+	proto.SetExtension(shelfOpts, binding.E_Location, binding.BindingLocation_BINDING_LOCATION_HEADER)
+
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("http.proto"),
+		Package:    proto.String("api.v1"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"sphere/binding/binding.proto"},
+		Options: &descriptorpb.FileOptions{
+			GoPackage: proto.String("github.com/example/api/v1;apiv1"),
+		},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name:    proto.String("GetBookRequest"),
+				Options: requestOpts,
+				Field: []*descriptorpb.FieldDescriptorProto{
+					stringField("name", 1, nil),
+					stringField("shelf", 2, shelfOpts),
+					{
+						Name:     proto.String("book"),
+						Number:   proto.Int32(3),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+						TypeName: proto.String(".api.v1.GetBookRequest.Book"),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+						JsonName: proto.String("book"),
+					},
+					stringField("page", 4, nil),
+				},
+				NestedType: []*descriptorpb.DescriptorProto{
+					{
+						Name:  proto.String("Book"),
+						Field: []*descriptorpb.FieldDescriptorProto{stringField("id", 1, nil)},
+					},
+				},
+			},
+			{
+				Name:  proto.String("GetBookResponse"),
+				Field: []*descriptorpb.FieldDescriptorProto{stringField("title", 1, nil)},
+			},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{
+			{
+				Name: proto.String("BookService"),
+				Method: []*descriptorpb.MethodDescriptorProto{
+					{
+						Name:       proto.String("GetBook"),
+						InputType:  proto.String(".api.v1.GetBookRequest"),
+						OutputType: proto.String(".api.v1.GetBookResponse"),
+						Options:    httpMethodOptions(rule),
+					},
+				},
+			},
+		},
+	}
+
+	req := &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{"http.proto"},
+		ProtoFile: []*descriptorpb.FileDescriptorProto{
+			protodesc.ToFileDescriptorProto(descriptorpb.File_google_protobuf_descriptor_proto),
+			protodesc.ToFileDescriptorProto(binding.File_sphere_binding_binding_proto),
+			fd,
+		},
+	}
+	plugin, err := protogen.Options{}.New(req)
+	if err != nil {
+		t.Fatalf("failed to create plugin: %v", err)
+	}
+	for _, f := range plugin.Files {
+		if f.Generate {
+			return f
+		}
+	}
+	t.Fatal("no file marked for generation")
+	return nil
+}
+
+func TestParsePathVariables(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     []string
+		wantErr  bool
+	}{
+		{"none", "/api/users", nil, false},
+		{"simple", "/api/test/{path_test1}/second/{path_test2}", []string{"path_test1", "path_test2"}, false},
+		{"nested", "/v1/books/{book.id}", []string{"book.id"}, false},
+		{"pattern", "/v1/{name=shelves/*/books/*}:get", []string{"name"}, false},
+		{"unterminated", "/v1/{name", nil, true},
+		{"empty", "/v1/{}", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePathVariables(tt.template)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePathVariables(%q) error = %v, wantErr %v", tt.template, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("parsePathVariables(%q) = %v, want %v", tt.template, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseHTTPRule(t *testing.T) {
+	raw := encodeHTTPRule(httpRulePost, "/v1/books/{name}", "*")
+	raw = protowire.AppendTag(raw, httpRuleAdditionalBindings, protowire.BytesType)
+	raw = protowire.AppendBytes(raw, encodeHTTPRule(httpRuleGet, "/v1/books/{book.id}", ""))
+
+	rule, err := parseHTTPRule(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &httpRule{
+		Method: "POST",
+		Path:   "/v1/books/{name}",
+		Body:   "*",
+		AdditionalBindings: []*httpRule{
+			{Method: "GET", Path: "/v1/books/{book.id}"},
+		},
+	}
+	if !reflect.DeepEqual(rule, want) {
+		t.Fatalf("parseHTTPRule = %+v, want %+v", rule, want)
+	}
+}
+
+// httpSplitTestFiles builds a plugin with messages.proto, declaring
+// GetBookRequest with a "name" field, and service.proto, routing its GetBook
+// method by rule with GetBookRequest as input. It returns both files.
+func httpSplitTestFiles(t *testing.T, rule []byte) (messages, service *protogen.File) {
+	t.Helper()
+
+	goPackage := &descriptorpb.FileOptions{GoPackage: proto.String("github.com/example/api/v1;apiv1")}
+	messagesFile := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("messages.proto"),
+		Package: proto.String("api.v1"),
+		Syntax:  proto.String("proto3"),
+		Options: goPackage,
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("GetBookRequest"), Field: []*descriptorpb.FieldDescriptorProto{stringField("name", 1, nil)}},
+			{Name: proto.String("GetBookResponse")},
+		},
+	}
+	serviceFile := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("service.proto"),
+		Package:    proto.String("api.v1"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"messages.proto"},
+		Options:    goPackage,
+		Service: []*descriptorpb.ServiceDescriptorProto{
+			{
+				Name: proto.String("BookService"),
+				Method: []*descriptorpb.MethodDescriptorProto{
+					{
+						Name:       proto.String("GetBook"),
+						InputType:  proto.String(".api.v1.GetBookRequest"),
+						OutputType: proto.String(".api.v1.GetBookResponse"),
+						Options:    httpMethodOptions(rule),
+					},
+				},
+			},
+		},
+	}
+
+	plugin, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{"messages.proto", "service.proto"},
+		ProtoFile:      []*descriptorpb.FileDescriptorProto{messagesFile, serviceFile},
+	})
+	if err != nil {
+		t.Fatalf("failed to create plugin: %v", err)
+	}
+	return plugin.FilesByPath["messages.proto"], plugin.FilesByPath["service.proto"]
+}
+
+func TestExtractFile_InferFromOtherFiles(t *testing.T) {
+	messages, service := httpSplitTestFiles(t, encodeHTTPRule(httpRuleGet, "/v1/books/{name}", ""))
+	cfg := DefaultConfig()
+	cfg.InferURILocations = true
+
+	tags, err := extractFile(messages, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := tags["GetBookRequest"]["Name"]; ok {
+		t.Fatalf("expected no inferred tags without service files, got %v", tags)
+	}
+
+	cfg.ServiceFiles = []*protogen.File{messages, service}
+	tags, err = extractFile(messages, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := tags["GetBookRequest"]["Name"]; got == nil || got.String() != `uri:"name" json:"-"` {
+		t.Errorf("GetBookRequest.Name tags = %v, want the uri location from service.proto", got)
+	}
+}
+
+func TestExtractFile_InferURILocations(t *testing.T) {
+	rule := encodeHTTPRule(httpRuleGet, "/v1/shelves/{shelf}/books/{name}/{book.id}", "")
+
+	t.Run("disabled by default", func(t *testing.T) {
+		tags, err := extractFile(httpTestFile(t, rule), DefaultConfig())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := tags["GetBookRequest"]["Name"]; ok {
+			t.Fatalf("expected no inferred tags, got %v", tags)
+		}
+	})
+
+	t.Run("path variables bind to uri", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.InferURILocations = true
+		tags, err := extractFile(httpTestFile(t, rule), cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := map[string]map[string]string{
+			"GetBookRequest":      {"Name": `uri:"name" json:"-"`, "Shelf": `header:"Shelf" json:"-"`},
+			"GetBookRequest_Book": {"Id": `uri:"id" json:"-"`},
+		}
+		for structName, fields := range want {
+			for fieldName, value := range fields {
+				got, ok := tags[structName][fieldName]
+				if !ok {
+					t.Fatalf("missing tags for %s.%s, got %v", structName, fieldName, tags)
+				}
+				if got.String() != value {
+					t.Errorf("%s.%s tags = %q, want %q", structName, fieldName, got.String(), value)
+				}
+			}
+		}
+	})
+
+	t.Run("path variables win over message default location", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.InferURILocations = true
+		opts := &descriptorpb.MessageOptions{}
+		proto.SetExtension(opts, binding.E_DefaultLocation, binding.BindingLocation_BINDING_LOCATION_QUERY)
+		tags, err := extractFile(httpTestFileWithOptions(t, encodeHTTPRule(httpRuleGet, "/v1/books/{name}", ""), opts), cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := map[string]string{
+			"Name": `uri:"name" json:"-"`,
+			"Page": `query:"page" json:"-"`,
+		}
+		for fieldName, value := range want {
+			if got := tags["GetBookRequest"][fieldName]; got == nil || got.String() != value {
+				t.Errorf("GetBookRequest.%s tags = %v, want %q", fieldName, got, value)
+			}
+		}
+	})
+
+	t.Run("unknown path variable is an error", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.InferURILocations = true
+		_, err := extractFile(httpTestFile(t, encodeHTTPRule(httpRuleGet, "/v1/{missing}", "")), cfg)
+		if err == nil {
+			t.Fatal("expected an error for a path variable without a matching field")
+		}
+	})
+}
+
+func TestExtractFile_InferHTTPLocations(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.InferHTTPLocations = true
+
+	t.Run("body field binds to json and the rest to query", func(t *testing.T) {
+		rule := encodeHTTPRule(httpRulePost, "/v1/books/{name}", "book")
+		tags, err := extractFile(httpTestFile(t, rule), cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := map[string]string{
+			"Name":  `uri:"name" json:"-"`,
+			"Shelf": `header:"Shelf" json:"-"`,
+			"Page":  `query:"page" json:"-"`,
+		}
+		for fieldName, value := range want {
+			got, ok := tags["GetBookRequest"][fieldName]
+			if !ok {
+				t.Fatalf("missing tags for GetBookRequest.%s, got %v", fieldName, tags)
+			}
+			if got.String() != value {
+				t.Errorf("GetBookRequest.%s tags = %q, want %q", fieldName, got.String(), value)
+			}
+		}
+		if _, ok := tags["GetBookRequest"]["Book"]; ok {
+			t.Error("body field Book should keep its json tag untouched")
+		}
+	})
+
+	t.Run("wildcard body binds everything else to json", func(t *testing.T) {
+		rule := encodeHTTPRule(httpRulePost, "/v1/books/{name}", "*")
+		tags, err := extractFile(httpTestFile(t, rule), cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := tags["GetBookRequest"]["Page"]; ok {
+			t.Errorf("Page should bind to the json body, got %v", tags["GetBookRequest"]["Page"])
+		}
+	})
+
+	t.Run("message default location wins over body and query", func(t *testing.T) {
+		opts := &descriptorpb.MessageOptions{}
+		proto.SetExtension(opts, binding.E_DefaultLocation, binding.BindingLocation_BINDING_LOCATION_FORM)
+		rule := encodeHTTPRule(httpRulePost, "/v1/books/{name}", "book")
+		tags, err := extractFile(httpTestFileWithOptions(t, rule, opts), cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := map[string]string{
+			"Name":  `uri:"name" json:"-"`,
+			"Shelf": `header:"Shelf" json:"-"`,
+			"Page":  `form:"page" json:"-"`,
+		}
+		for fieldName, value := range want {
+			got, ok := tags["GetBookRequest"][fieldName]
+			if !ok {
+				t.Fatalf("missing tags for GetBookRequest.%s, got %v", fieldName, tags)
+			}
+			if got.String() != value {
+				t.Errorf("GetBookRequest.%s tags = %q, want %q", fieldName, got.String(), value)
+			}
+		}
+		if got := tags["GetBookRequest_Book"]["Id"]; got == nil || got.String() != `form:"id" json:"-"` {
+			t.Errorf("GetBookRequest_Book.Id tags = %v, want the inherited form location", got)
+		}
+	})
+
+	t.Run("unknown body field is an error", func(t *testing.T) {
+		rule := encodeHTTPRule(httpRulePost, "/v1/books/{name}", "missing")
+		if _, err := extractFile(httpTestFile(t, rule), cfg); err == nil {
+			t.Fatal("expected an error for a body without a matching field")
+		}
+	})
+}
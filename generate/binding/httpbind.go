@@ -0,0 +1,284 @@
+package binding
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	errorsPackage  = protogen.GoImportPath("errors")
+	fmtPackage     = protogen.GoImportPath("fmt")
+	httpPackage    = protogen.GoImportPath("net/http")
+	strconvPackage = protogen.GoImportPath("strconv")
+)
+
+// httpSources are the locations a generated BindHTTPRequest method reads, in
+// the order it reads them.
+var httpSources = []string{"uri", "query", "header", "cookie", "form"}
+
+// httpSourceNames describe each location in generated error messages.
+var httpSourceNames = map[string]string{
+	"uri":    "path parameter",
+	"query":  "query parameter",
+	"header": "header",
+	"cookie": "cookie",
+	"form":   "form value",
+}
+
+// httpField is a field a generated BindHTTPRequest method sets, with the
+// location it is read from and the key it is read with.
+type httpField struct {
+	field    *protogen.Field
+	location string
+	key      string
+}
+
+// httpMessage is a message with a generated BindHTTPRequest method. Fields
+// bound outside the JSON body that the method cannot set are listed in
+// unbound.
+type httpMessage struct {
+	message *protogen.Message
+	fields  []httpField
+	unbound []string
+}
+
+// GenerateHTTPBinders emits <prefix>.binding_http.go, declaring for every
+// message of file with a field bound to the uri, query, header, cookie or form
+// location a BindHTTPRequest method. The method parses the fields from a
+// net/http request without reflection, using the same resolved locations and
+// tag values as the struct tags. It emits nothing when no message qualifies.
+func GenerateHTTPBinders(gen *protogen.Plugin, file *protogen.File, config *Config) error {
+	messages, err := httpMessages(file, config)
+	if err != nil {
+		return err
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+
+	g := gen.NewGeneratedFile(file.GeneratedFilenamePrefix+".binding_http.go", file.GoImportPath)
+	g.P("// Code generated by protoc-gen-sphere-binding. DO NOT EDIT.")
+	g.P("// source: ", file.Desc.Path())
+	g.P()
+	g.P("package ", file.GoPackageName)
+	for _, message := range messages {
+		generateHTTPBinder(g, message)
+	}
+	return nil
+}
+
+// httpMessages returns the messages of file, nested ones included, that get a
+// BindHTTPRequest method, in declaration order.
+func httpMessages(file *protogen.File, config *Config) ([]*httpMessage, error) {
+	_, bindings, err := extractFileBindings(file, config)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[protoreflect.FullName]*httpMessage)
+	for _, b := range bindings {
+		location := config.locationName(b.scope.location)
+		if location == "json" {
+			continue
+		}
+		name := b.field.Parent.Desc.FullName()
+		message := byName[name]
+		if message == nil {
+			message = &httpMessage{message: b.field.Parent}
+			byName[name] = message
+		}
+		tag, err := b.tags.Get(locationTagKey(location, config))
+		if err != nil || !slices.Contains(httpSources, location) || !httpBindable(b.field) {
+			message.unbound = append(message.unbound, string(b.field.Desc.Name()))
+			continue
+		}
+		message.fields = append(message.fields, httpField{field: b.field, location: location, key: tag.Name})
+	}
+
+	var messages []*httpMessage
+	var walk func([]*protogen.Message)
+	walk = func(list []*protogen.Message) {
+		for _, m := range list {
+			if message := byName[m.Desc.FullName()]; message != nil && len(message.fields) > 0 {
+				slices.SortStableFunc(message.fields, func(a, b httpField) int {
+					return a.field.Desc.Index() - b.field.Desc.Index()
+				})
+				messages = append(messages, message)
+			}
+			walk(m.Messages)
+		}
+	}
+	walk(file.Messages)
+	return messages, nil
+}
+
+// locationTagKey returns the tag key of the location named name.
+func locationTagKey(name string, config *Config) string {
+	location, err := config.location(name)
+	if err != nil {
+		return ""
+	}
+	key, _ := config.locationTag(location)
+	return key
+}
+
+// httpBindable reports whether a BindHTTPRequest method can parse field from
+// strings: scalars, enums and repeated ones, but not messages or maps.
+func httpBindable(field *protogen.Field) bool {
+	if field.Desc.IsMap() {
+		return false
+	}
+	switch field.Desc.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return false
+	default:
+		return true
+	}
+}
+
+func generateHTTPBinder(g *protogen.GeneratedFile, message *httpMessage) {
+	var used []string
+	for _, source := range httpSources {
+		if slices.ContainsFunc(message.fields, func(f httpField) bool { return f.location == source }) {
+			used = append(used, source)
+		}
+	}
+
+	g.P()
+	from := "r"
+	if slices.Contains(used, "uri") {
+		from = "r and pathParams"
+	}
+	g.P("// BindHTTPRequest sets the ", joinNames(used), " fields of x from ", from, ".")
+	g.P("// Fields absent from the request are left unchanged.")
+	if len(message.unbound) > 0 {
+		g.P("// It does not set ", joinNames(message.unbound), ".")
+	}
+	g.P("func (x *", message.message.GoIdent.GoName, ") BindHTTPRequest(r *", g.QualifiedGoIdent(httpPackage.Ident("Request")), ", pathParams map[string]string) error {")
+	if slices.Contains(used, "query") {
+		g.P("query := r.URL.Query()")
+	}
+	if slices.Contains(used, "form") {
+		g.P("if err := r.ParseMultipartForm(32 << 20); err != nil && !", g.QualifiedGoIdent(errorsPackage.Ident("Is")), "(err, ", g.QualifiedGoIdent(httpPackage.Ident("ErrNotMultipart")), ") {")
+		g.P("return ", g.QualifiedGoIdent(fmtPackage.Ident("Errorf")), `("parse form: %w", err)`)
+		g.P("}")
+	}
+	for _, source := range httpSources {
+		for _, f := range message.fields {
+			if f.location == source {
+				generateHTTPField(g, f)
+			}
+		}
+	}
+	g.P("return nil")
+	g.P("}")
+}
+
+func generateHTTPField(g *protogen.GeneratedFile, f httpField) {
+	key := strconv.Quote(f.key)
+	// The path parameters and cookies hold a single value, the others a list.
+	var single string
+	switch f.location {
+	case "uri":
+		g.P("if value, ok := pathParams[", key, "]; ok {")
+		single = "value"
+	case "cookie":
+		g.P("if cookie, err := r.Cookie(", key, "); err == nil {")
+		single = "cookie.Value"
+	case "query":
+		g.P("if values := query[", key, "]; len(values) > 0 {")
+	case "header":
+		g.P("if values := r.Header.Values(", key, "); len(values) > 0 {")
+	case "form":
+		g.P("if values := r.PostForm[", key, "]; len(values) > 0 {")
+	}
+
+	field := f.field
+	switch {
+	case field.Desc.IsList() && single != "":
+		value := httpParse(g, f, single)
+		g.P("x.", field.GoName, " = append(x.", field.GoName, "[:0], ", value, ")")
+	case field.Desc.IsList():
+		g.P("x.", field.GoName, " = x.", field.GoName, "[:0]")
+		g.P("for _, value := range values {")
+		value := httpParse(g, f, "value")
+		g.P("x.", field.GoName, " = append(x.", field.GoName, ", ", value, ")")
+		g.P("}")
+	default:
+		in := single
+		if in == "" {
+			in = "values[0]"
+		}
+		value := httpParse(g, f, in)
+		switch {
+		case field.Oneof != nil && !field.Oneof.Desc.IsSynthetic():
+			g.P("x.", field.Oneof.GoName, " = &", g.QualifiedGoIdent(field.GoIdent), "{", field.GoName, ": ", value, "}")
+		case field.Desc.HasPresence() && field.Desc.Kind() != protoreflect.BytesKind:
+			// protoc-gen-go represents an unset bytes field as nil, not a pointer.
+			g.P("v := ", value)
+			g.P("x.", field.GoName, " = &v")
+		default:
+			g.P("x.", field.GoName, " = ", value)
+		}
+	}
+	g.P("}")
+}
+
+// httpParse emits the statements parsing the string expression in into the Go
+// type of the field and returns the expression of the parsed value.
+func httpParse(g *protogen.GeneratedFile, f httpField, in string) string {
+	field := f.field
+	// fmt is only imported by fields that can fail to parse.
+	errorf := func() string { return g.QualifiedGoIdent(fmtPackage.Ident("Errorf")) }
+	context := fmt.Sprintf("invalid %s %q (%s)", httpSourceNames[f.location], f.key, field.Desc.FullName())
+	parse := func(fn, args, convert string) string {
+		g.P("n, err := ", g.QualifiedGoIdent(strconvPackage.Ident(fn)), "(", in, args, ")")
+		g.P("if err != nil {")
+		g.P("return ", errorf(), "(", strconv.Quote(context+": %w"), ", err)")
+		g.P("}")
+		if convert == "" {
+			return "n"
+		}
+		return convert + "(n)"
+	}
+
+	switch field.Desc.Kind() {
+	case protoreflect.StringKind:
+		return in
+	case protoreflect.BytesKind:
+		return "[]byte(" + in + ")"
+	case protoreflect.BoolKind:
+		return parse("ParseBool", "", "")
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return parse("ParseInt", ", 10, 32", "int32")
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return parse("ParseInt", ", 10, 64", "")
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return parse("ParseUint", ", 10, 32", "uint32")
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return parse("ParseUint", ", 10, 64", "")
+	case protoreflect.FloatKind:
+		return parse("ParseFloat", ", 32", "float32")
+	case protoreflect.DoubleKind:
+		return parse("ParseFloat", ", 64", "")
+	case protoreflect.EnumKind:
+		enum := field.Enum.GoIdent
+		values := g.QualifiedGoIdent(protogen.GoIdent{GoName: enum.GoName + "_value", GoImportPath: enum.GoImportPath})
+		// Enum values bind by name, or by number.
+		g.P("n, ok := ", values, "[", in, "]")
+		g.P("if !ok {")
+		g.P("i, err := ", g.QualifiedGoIdent(strconvPackage.Ident("ParseInt")), "(", in, ", 10, 32)")
+		g.P("if err != nil {")
+		g.P("return ", errorf(), "(", strconv.Quote(context+": unknown "+string(field.Enum.Desc.FullName())+" value %q"), ", ", in, ")")
+		g.P("}")
+		g.P("n = int32(i)")
+		g.P("}")
+		return g.QualifiedGoIdent(enum) + "(n)"
+	default:
+		panic(fmt.Sprintf("unsupported kind %s", field.Desc.Kind()))
+	}
+}
@@ -0,0 +1,222 @@
+package binding
+
+import (
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-sphere-binding/generate/internal/testutil"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+func TestGenerateHTTPBinders(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/httpbind.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "httpbind.proto")
+	file := testutil.FileToGenerate(t, plugin)
+
+	if err := GenerateHTTPBinders(plugin, file, DefaultConfig()); err != nil {
+		t.Fatal(err)
+	}
+	resp := plugin.Response()
+	if resp.Error != nil {
+		t.Fatal(resp.GetError())
+	}
+	if len(resp.File) != 1 {
+		t.Fatalf("expected one generated file, got %d", len(resp.File))
+	}
+	if got, want := resp.File[0].GetName(), file.GeneratedFilenamePrefix+".binding_http.go"; got != want {
+		t.Errorf("file name = %q, want %q", got, want)
+	}
+	content := resp.File[0].GetContent()
+
+	for _, want := range []string{
+		`// BindHTTPRequest sets the uri, query, header and form fields of x from r and pathParams.
+// Fields absent from the request are left unchanged.
+// It does not set filter and extra.
+func (x *ListRequest) BindHTTPRequest(r *http.Request, pathParams map[string]string) error {
+	query := r.URL.Query()
+	if err := r.ParseMultipartForm(32 << 20); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		return fmt.Errorf("parse form: %w", err)
+	}
+	if value, ok := pathParams["project_id"]; ok {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid path parameter \"project_id\" (testdata.httpbind.v1.ListRequest.project_id): %w", err)
+		}
+		x.ProjectId = n
+	}
+`,
+		`	if values := query["statuses"]; len(values) > 0 {
+		x.Statuses = x.Statuses[:0]
+		for _, value := range values {
+			n, ok := Status_value[value]
+			if !ok {
+				i, err := strconv.ParseInt(value, 10, 32)
+				if err != nil {
+					return fmt.Errorf("invalid query parameter \"statuses\" (testdata.httpbind.v1.ListRequest.statuses): unknown testdata.httpbind.v1.Status value %q", value)
+				}
+				n = int32(i)
+			}
+			x.Statuses = append(x.Statuses, Status(n))
+		}
+	}
+`,
+		`		v := int32(n)
+		x.Limit = &v
+`,
+		`		x.Order = &ListRequest_Newest{Newest: n}
+`,
+		`	if values := r.Header.Values("Trace"); len(values) > 0 {
+`,
+		`	if values := r.PostForm["note"]; len(values) > 0 {
+`,
+		`func (x *ListRequest_Filter) BindHTTPRequest(r *http.Request, pathParams map[string]string) error {
+`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("generated file is missing:\n%s\ngot:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, `"body"`) {
+		t.Error("JSON fields must not be bound")
+	}
+}
+
+func TestGenerateHTTPBinders_Proto2(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/httpbind_proto2.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "httpbind_proto2.proto")
+	file := testutil.FileToGenerate(t, plugin)
+
+	if err := GenerateHTTPBinders(plugin, file, DefaultConfig()); err != nil {
+		t.Fatal(err)
+	}
+	content := plugin.Response().File[0].GetContent()
+	for _, want := range []string{
+		"\t\tx.Data = []byte(values[0])\n",
+		"\t\tv := int32(n)\n\t\tx.Size = &v\n",
+		"\t\tv := Kind(n)\n\t\tx.Kind = &v\n",
+		"\t\tv := values[0]\n\t\tx.Name = &v\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("generated file is missing %q:\n%s", want, content)
+		}
+	}
+}
+
+// generateGoFiles runs the given generators on the fixture name and returns
+// their output together with the fixture's protoc-gen-go output, keyed by file
+// name, ready for testutil.GoTest.
+func generateGoFiles(t *testing.T, name string, generators ...func(*protogen.Plugin, *protogen.File) error) map[string]string {
+	t.Helper()
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/"+name+".pb")
+	plugin := testutil.MustCreatePlugin(t, set, name+".proto")
+	file := testutil.FileToGenerate(t, plugin)
+	for _, generate := range generators {
+		if err := generate(plugin, file); err != nil {
+			t.Fatal(err)
+		}
+	}
+	resp := plugin.Response()
+	if resp.Error != nil {
+		t.Fatal(resp.GetError())
+	}
+
+	pbGo, err := os.ReadFile("testdata/gen/" + name + ".pb.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{name + ".pb.go": string(pbGo)}
+	for _, f := range resp.File {
+		files[path.Base(f.GetName())] = f.GetContent()
+	}
+	return files
+}
+
+func httpBindersAndEnums(plugin *protogen.Plugin, file *protogen.File) error {
+	if err := GenerateHTTPBinders(plugin, file, DefaultConfig()); err != nil {
+		return err
+	}
+	return GenerateEnumUnmarshalers(plugin, DefaultConfig())
+}
+
+func TestGenerateHTTPBinders_Run(t *testing.T) {
+	t.Run("proto3", func(t *testing.T) {
+		files := generateGoFiles(t, "httpbind", httpBindersAndEnums)
+		files["bind_test.go"] = `package httpbindv1
+
+import (
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBindHTTPRequest(t *testing.T) {
+	query := "page=2&cursor=9&archived=true&min_score=0.5&max_score=1.5&token=abc" +
+		"&status=STATUS_ACTIVE&statuses=STATUS_ACTIVE&statuses=2&labels=a&labels=b&limit=10&newest=true&name=f"
+	r := httptest.NewRequest("POST", "/v1/projects/7?"+query, strings.NewReader("note=hello"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("Trace", "t-1")
+
+	x := &ListRequest{Body: "kept"}
+	if err := x.BindHTTPRequest(r, map[string]string{"project_id": "7"}); err != nil {
+		t.Fatal(err)
+	}
+	if x.ProjectId != 7 || x.Trace != "t-1" || x.Page != 2 || x.Cursor != 9 || !x.Archived {
+		t.Errorf("scalars = %d %q %d %d %v", x.ProjectId, x.Trace, x.Page, x.Cursor, x.Archived)
+	}
+	if x.MinScore != 0.5 || x.MaxScore != 1.5 || string(x.Token) != "abc" || x.Note != "hello" || x.Body != "kept" {
+		t.Errorf("scores, token, note and body = %v %v %q %q %q", x.MinScore, x.MaxScore, x.Token, x.Note, x.Body)
+	}
+	if x.Status != Status_STATUS_ACTIVE || !slices.Equal(x.Statuses, []Status{Status_STATUS_ACTIVE, Status_STATUS_ARCHIVED}) {
+		t.Errorf("enums = %v %v", x.Status, x.Statuses)
+	}
+	if !slices.Equal(x.Labels, []string{"a", "b"}) || x.Limit == nil || *x.Limit != 10 || !x.GetNewest() {
+		t.Errorf("labels, limit and order = %v %v %v", x.Labels, x.Limit, x.Order)
+	}
+
+	bad := httptest.NewRequest("GET", "/?statuses=STATUS_GONE", nil)
+	if err := new(ListRequest).BindHTTPRequest(bad, nil); err == nil {
+		t.Error("expected an error for an unknown enum value")
+	}
+}
+`
+		testutil.GoTest(t, files)
+	})
+
+	t.Run("proto2", func(t *testing.T) {
+		files := generateGoFiles(t, "httpbind_proto2", httpBindersAndEnums)
+		files["bind_test.go"] = `package httpbindproto2v1
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBindHTTPRequest(t *testing.T) {
+	r := httptest.NewRequest("GET", "/?data=xyz&size=3&kind=KIND_FILE&name=n", nil)
+	x := new(LegacyRequest)
+	if err := x.BindHTTPRequest(r, nil); err != nil {
+		t.Fatal(err)
+	}
+	if string(x.Data) != "xyz" || x.GetSize() != 3 || x.GetKind() != Kind_KIND_FILE || x.GetName() != "n" {
+		t.Errorf("LegacyRequest = %v", x)
+	}
+}
+`
+		testutil.GoTest(t, files)
+	})
+}
+
+func TestHTTPMessages_NoBinding(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/no_binding.pb")
+	file := testutil.FileToGenerate(t, testutil.MustCreatePlugin(t, set, "no_binding.proto"))
+	messages, err := httpMessages(file, DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 0 {
+		t.Fatalf("expected no messages, got %d", len(messages))
+	}
+}
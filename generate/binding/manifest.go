@@ -0,0 +1,166 @@
+package binding
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/fatih/structtag"
+	"github.com/go-sphere/binding/sphere/binding"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// fieldBinding records how the binding of one field was resolved: the Go
+// struct holding it, its final scope and the tags generated for it.
+type fieldBinding struct {
+	field      *protogen.Field
+	structName string
+	scope      bindingScope
+	tags       *structtag.Tags
+}
+
+// Manifest lists, for one proto file, where every field of its messages binds.
+// It is emitted as <prefix>.binding.json for tooling such as gateways, docs
+// and client generators.
+type Manifest struct {
+	File     string             `json:"file"`
+	GoFile   string             `json:"go_file"`
+	Messages []*MessageManifest `json:"messages"`
+}
+
+// MessageManifest is a message of a Manifest and its Go struct.
+type MessageManifest struct {
+	Message string           `json:"message"`
+	Struct  string           `json:"struct"`
+	Fields  []*FieldManifest `json:"fields"`
+}
+
+// FieldManifest is the resolved binding of a field. Struct differs from the
+// message struct for oneof members, which live in a wrapper struct. Tags are
+// the tags the plugin sets, not the full struct tag, and RemovedTags the keys
+// it removes.
+type FieldManifest struct {
+	Field          string            `json:"field"`
+	GoField        string            `json:"go_field"`
+	Struct         string            `json:"struct"`
+	Oneof          string            `json:"oneof,omitempty"`
+	Location       string            `json:"location"`
+	LocationSource BindingSource     `json:"location_source"`
+	AutoTags       []string          `json:"auto_tags,omitempty"`
+	AutoTagsSource BindingSource     `json:"auto_tags_source"`
+	Tags           map[string]string `json:"tags,omitempty"`
+	RemovedTags    []string          `json:"removed_tags,omitempty"`
+}
+
+// ParseManifestFormat validates a manifest value; empty disables the manifest.
+func ParseManifestFormat(format string) (string, error) {
+	switch format {
+	case "", "json":
+		return format, nil
+	default:
+		return "", fmt.Errorf("invalid manifest format '%s': want 'json'", format)
+	}
+}
+
+// GenerateManifest emits the Manifest of file as <prefix>.binding.json.
+func GenerateManifest(gen *protogen.Plugin, file *protogen.File, config *Config) error {
+	manifest, err := buildManifest(file, config)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = gen.NewGeneratedFile(file.GeneratedFilenamePrefix+".binding.json", "").Write(append(data, '\n'))
+	return err
+}
+
+// buildManifest resolves the bindings of file into a Manifest. Messages are
+// listed in declaration order, nested messages after their parent, and fields
+// in declaration order. Map entries have no struct and are left out.
+func buildManifest(file *protogen.File, config *Config) (*Manifest, error) {
+	_, bindings, err := extractFileBindings(file, config)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{
+		File:     file.Desc.Path(),
+		GoFile:   file.GeneratedFilenamePrefix + ".pb.go",
+		Messages: []*MessageManifest{},
+	}
+	byName := make(map[protoreflect.FullName]*MessageManifest)
+	var walk func(messages []*protogen.Message)
+	walk = func(messages []*protogen.Message) {
+		for _, message := range messages {
+			if message.Desc.IsMapEntry() {
+				continue
+			}
+			entry := &MessageManifest{
+				Message: string(message.Desc.FullName()),
+				Struct:  message.GoIdent.GoName,
+				Fields:  []*FieldManifest{},
+			}
+			manifest.Messages = append(manifest.Messages, entry)
+			byName[message.Desc.FullName()] = entry
+			walk(message.Messages)
+		}
+	}
+	walk(file.Messages)
+
+	// Oneof members are resolved after the other fields of their message.
+	slices.SortStableFunc(bindings, func(a, b fieldBinding) int {
+		return a.field.Desc.Index() - b.field.Desc.Index()
+	})
+	for _, b := range bindings {
+		entry := byName[b.field.Parent.Desc.FullName()]
+		entry.Fields = append(entry.Fields, fieldManifest(b, config))
+	}
+	return manifest, nil
+}
+
+func fieldManifest(b fieldBinding, config *Config) *FieldManifest {
+	field := &FieldManifest{
+		Field:          string(b.field.Desc.Name()),
+		GoField:        b.field.GoName,
+		Struct:         b.structName,
+		Location:       config.locationName(b.scope.location),
+		LocationSource: b.scope.locationSource,
+		AutoTags:       b.scope.autoTags,
+		AutoTagsSource: b.scope.autoTagsSource,
+	}
+	if b.field.Oneof != nil && !b.field.Oneof.Desc.IsSynthetic() {
+		field.Oneof = string(b.field.Oneof.Desc.Name())
+	}
+	for _, tag := range b.tags.Tags() {
+		if key, remove := strings.CutPrefix(tag.Key, removeTagPrefix); remove {
+			field.RemovedTags = append(field.RemovedTags, key)
+			continue
+		}
+		if field.Tags == nil {
+			field.Tags = make(map[string]string)
+		}
+		field.Tags[tag.Key] = tag.Value()
+	}
+	return field
+}
+
+// locationName returns the parameter name of location, e.g. "query", or
+// "json" for fields bound to the JSON body, including unannotated ones.
+func (c *Config) locationName(location binding.BindingLocation) string {
+	if location == binding.BindingLocation_BINDING_LOCATION_UNSPECIFIED {
+		return "json"
+	}
+	for name, known := range bindingLocationNames {
+		if known == location {
+			return name
+		}
+	}
+	if i := int(location - customLocationBase); location >= customLocationBase && i < len(c.CustomLocations) {
+		return c.CustomLocations[i].Name
+	}
+	return location.String()
+}
@@ -0,0 +1,79 @@
+package binding
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-sphere-binding/generate/internal/testutil"
+)
+
+func TestBuildManifest(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/oneof.pb")
+	file := testutil.FileToGenerate(t, testutil.MustCreatePlugin(t, set, "oneof.proto"))
+
+	manifest, err := buildManifest(file, DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.File != "oneof.proto" {
+		t.Errorf("File = %q, want oneof.proto", manifest.File)
+	}
+
+	fields := make(map[string]*FieldManifest)
+	var messages []string
+	for _, message := range manifest.Messages {
+		messages = append(messages, message.Message)
+		for _, field := range message.Fields {
+			fields[message.Message+"."+field.Field] = field
+		}
+	}
+	wantMessages := []string{
+		"testdata.oneof.v1.OneofRequest",
+		"testdata.oneof.v1.OneofRequest.Filter",
+		"testdata.oneof.v1.OneofResponse",
+	}
+	if !reflect.DeepEqual(messages, wantMessages) {
+		t.Fatalf("messages = %v, want %v", messages, wantMessages)
+	}
+
+	tests := []struct {
+		field    string
+		want     string
+		source   BindingSource
+		oneof    string
+		goStruct string
+	}{
+		{"testdata.oneof.v1.OneofRequest.outer", "query", BindingSource{LevelMessage, "testdata.oneof.v1.OneofRequest"}, "", "OneofRequest"},
+		{"testdata.oneof.v1.OneofRequest.by_name", "uri", BindingSource{LevelOneof, "testdata.oneof.v1.OneofRequest.selector"}, "selector", "OneofRequest_ByName"},
+		{"testdata.oneof.v1.OneofRequest.Filter.status", "query", BindingSource{LevelMessage, "testdata.oneof.v1.OneofRequest"}, "", "OneofRequest_Filter"},
+		{"testdata.oneof.v1.OneofResponse.ok", "json", BindingSource{Level: LevelDefault}, "", "OneofResponse"},
+	}
+	for _, tt := range tests {
+		field := fields[tt.field]
+		if field == nil {
+			t.Errorf("%s: missing from manifest", tt.field)
+			continue
+		}
+		if field.Location != tt.want || field.LocationSource != tt.source {
+			t.Errorf("%s: location = %s from %+v, want %s from %+v", tt.field, field.Location, field.LocationSource, tt.want, tt.source)
+		}
+		if field.Oneof != tt.oneof || field.Struct != tt.goStruct {
+			t.Errorf("%s: oneof/struct = %q/%q, want %q/%q", tt.field, field.Oneof, field.Struct, tt.oneof, tt.goStruct)
+		}
+	}
+
+	if got := fields["testdata.oneof.v1.OneofRequest.outer"].Tags["query"]; got != "outer" {
+		t.Errorf("outer query tag = %q, want outer", got)
+	}
+}
+
+func TestParseManifestFormat(t *testing.T) {
+	for _, format := range []string{"", "json"} {
+		if _, err := ParseManifestFormat(format); err != nil {
+			t.Errorf("ParseManifestFormat(%q) error = %v", format, err)
+		}
+	}
+	if _, err := ParseManifestFormat("yaml"); err == nil {
+		t.Error("expected an error for yaml")
+	}
+}
@@ -0,0 +1,191 @@
+package binding
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// NamingStrategy controls how a proto field name is turned into a tag value.
+type NamingStrategy string
+
+const (
+	// NamingProto uses the proto field name as declared, e.g. "auth_token".
+	NamingProto NamingStrategy = "proto"
+	// NamingJSON uses the proto JSON name, honoring a custom json_name.
+	NamingJSON NamingStrategy = "json"
+	// NamingCamel uses lowerCamelCase, e.g. "authToken".
+	NamingCamel NamingStrategy = "camel"
+	// NamingKebab uses lower-case words joined by dashes, e.g. "auth-token".
+	NamingKebab NamingStrategy = "kebab"
+	// NamingHeaderCanonical uses the canonical MIME header form, e.g.
+	// "Auth-Token".
+	NamingHeaderCanonical NamingStrategy = "header_canonical"
+)
+
+var namingStrategies = map[NamingStrategy]bool{
+	NamingProto:           true,
+	NamingJSON:            true,
+	NamingCamel:           true,
+	NamingKebab:           true,
+	NamingHeaderCanonical: true,
+}
+
+// ParseTagNaming parses comma-separated naming entries. An entry is either a
+// bare strategy, which becomes the default for every binding location, or
+// key=strategy, which applies to the binding location tagged with key (query,
+// uri, form or header). Example: "camel,header=header_canonical".
+func ParseTagNaming(namingStr string) (map[string]NamingStrategy, error) {
+	naming := make(map[string]NamingStrategy)
+	if namingStr == "" {
+		return naming, nil
+	}
+
+	for _, entry := range strings.Split(namingStr, ",") {
+		entry = strings.TrimSpace(entry)
+		if len(entry) == 0 {
+			continue
+		}
+
+		key, value, found := strings.Cut(entry, "=")
+		if !found {
+			key, value = "", key
+		}
+		key = strings.TrimSpace(key)
+		strategy := NamingStrategy(strings.TrimSpace(value))
+
+		if found {
+			if err := ValidateTagKey(key); err != nil {
+				return nil, fmt.Errorf("invalid tag naming '%s': %w", entry, err)
+			}
+		}
+		if !namingStrategies[strategy] {
+			return nil, fmt.Errorf("invalid tag naming '%s': unknown strategy '%s'", entry, strategy)
+		}
+
+		naming[key] = strategy
+	}
+
+	return naming, nil
+}
+
+// locationNaming holds the strategies binding locations use when TagNaming
+// does not name them explicitly. Header lookups in Gin and net/http go through
+// the canonical MIME form, so a literal "auth_token" header would never match.
+var locationNaming = map[string]NamingStrategy{
+	"header": NamingHeaderCanonical,
+}
+
+// ParseHeaderPrefix validates a prefix for derived header names such as "X-".
+// Only letters, digits, '-', '_' and '.' are accepted, which keeps the result a
+// valid header name and a safe struct tag value.
+func ParseHeaderPrefix(prefix string) (string, error) {
+	for _, r := range prefix {
+		if !isHeaderPrefixRune(r) {
+			return "", fmt.Errorf("invalid header prefix '%s': character %q is not allowed", prefix, r)
+		}
+	}
+	return prefix, nil
+}
+
+func isHeaderPrefixRune(r rune) bool {
+	return r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' || r == '.')
+}
+
+// bindingTagName returns the value of the binding location tag bindingKey (and
+// its aliases) for field. A strategy from a rule matching the field, or its
+// enclosing message or oneof, wins, then the strategy configured for
+// bindingKey, then the location default from locationNaming, then the
+// configured default strategy, which falls back to NamingProto. Header names additionally get config.HeaderPrefix unless they
+// already start with it.
+func bindingTagName(field *protogen.Field, bindingKey string, ruleNaming NamingStrategy, config *Config) string {
+	strategy, ok := ruleNaming, ruleNaming != ""
+	if !ok {
+		strategy, ok = config.TagNaming[bindingKey]
+	}
+	if !ok {
+		strategy, ok = locationNaming[bindingKey]
+	}
+	if !ok {
+		strategy = config.TagNaming[""]
+	}
+	name := applyNaming(strategy, field)
+	if bindingKey == "header" && config.HeaderPrefix != "" &&
+		!strings.HasPrefix(strings.ToLower(name), strings.ToLower(config.HeaderPrefix)) {
+		name = config.HeaderPrefix + name
+	}
+	return name
+}
+
+// applyNaming formats the name of field according to strategy. Unknown or empty
+// strategies fall back to NamingProto.
+func applyNaming(strategy NamingStrategy, field *protogen.Field) string {
+	name := string(field.Desc.Name())
+	switch strategy {
+	case NamingJSON:
+		return field.Desc.JSONName()
+	case NamingCamel:
+		words := splitWords(name)
+		for i := range words {
+			if i == 0 {
+				words[i] = strings.ToLower(words[i])
+			} else {
+				words[i] = titleWord(words[i])
+			}
+		}
+		return strings.Join(words, "")
+	case NamingKebab:
+		words := splitWords(name)
+		for i := range words {
+			words[i] = strings.ToLower(words[i])
+		}
+		return strings.Join(words, "-")
+	case NamingHeaderCanonical:
+		words := splitWords(name)
+		for i := range words {
+			words[i] = titleWord(words[i])
+		}
+		return strings.Join(words, "-")
+	default:
+		return name
+	}
+}
+
+// splitWords splits a proto identifier into words at underscores and at
+// lower-to-upper case transitions, so both "auth_token" and "authToken" yield
+// ["auth", "token"] (case preserved).
+func splitWords(name string) []string {
+	var words []string
+	var current []rune
+	var prev rune
+	for _, r := range name {
+		switch {
+		case r == '_' || r == '-':
+			if len(current) > 0 {
+				words = append(words, string(current))
+				current = nil
+			}
+		case unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev)) && len(current) > 0:
+			words = append(words, string(current))
+			current = []rune{r}
+		default:
+			current = append(current, r)
+		}
+		prev = r
+	}
+	if len(current) > 0 {
+		words = append(words, string(current))
+	}
+	return words
+}
+
+// titleWord upper-cases the first letter of word and lower-cases the rest.
+func titleWord(word string) string {
+	runes := []rune(strings.ToLower(word))
+	if len(runes) > 0 {
+		runes[0] = unicode.ToUpper(runes[0])
+	}
+	return string(runes)
+}
@@ -0,0 +1,128 @@
+package binding
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-sphere-binding/generate/internal/testutil"
+)
+
+func TestParseTagNaming(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    map[string]NamingStrategy
+		wantErr bool
+	}{
+		{"empty", "", map[string]NamingStrategy{}, false},
+		{"default", "camel", map[string]NamingStrategy{"": NamingCamel}, false},
+		{
+			name:  "default_and_per_location",
+			input: "json, header=header_canonical",
+			want:  map[string]NamingStrategy{"": NamingJSON, "header": NamingHeaderCanonical},
+		},
+		{"unknown_strategy", "snake", nil, true},
+		{"invalid_key", "que ry=camel", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTagNaming(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTagNaming(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ParseTagNaming(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitWords(t *testing.T) {
+	tests := map[string][]string{
+		"auth_token":  {"auth", "token"},
+		"authToken":   {"auth", "Token"},
+		"_leading__x": {"leading", "x"},
+		"page2_size":  {"page2", "size"},
+		"id":          {"id"},
+	}
+	for input, want := range tests {
+		if got := splitWords(input); !reflect.DeepEqual(got, want) {
+			t.Errorf("splitWords(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestApplyNaming(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/basic.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "basic.proto")
+	file := testutil.FileToGenerate(t, plugin)
+
+	var field = file.Messages[0].Fields[1] // header_token
+	tests := map[NamingStrategy]string{
+		"":                    "header_token",
+		NamingProto:           "header_token",
+		NamingJSON:            "headerToken",
+		NamingCamel:           "headerToken",
+		NamingKebab:           "header-token",
+		NamingHeaderCanonical: "Header-Token",
+	}
+	for strategy, want := range tests {
+		if got := applyNaming(strategy, field); got != want {
+			t.Errorf("applyNaming(%q) = %q, want %q", strategy, got, want)
+		}
+	}
+}
+
+func TestBindingTagName(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/basic.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "basic.proto")
+	file := testutil.FileToGenerate(t, plugin)
+
+	var field = file.Messages[0].Fields[1] // header_token
+	tests := []struct {
+		name   string
+		key    string
+		naming map[string]NamingStrategy
+		prefix string
+		want   string
+	}{
+		{"query_default", "query", nil, "", "header_token"},
+		{"header_default", "header", nil, "", "Header-Token"},
+		{"header_ignores_bare_default", "header", map[string]NamingStrategy{"": NamingCamel}, "", "Header-Token"},
+		{"header_explicit", "header", map[string]NamingStrategy{"header": NamingProto}, "", "header_token"},
+		{"header_prefix", "header", nil, "X-", "X-Header-Token"},
+		{"header_prefix_present", "header", nil, "header-", "Header-Token"},
+		{"prefix_only_for_header", "query", nil, "X-", "header_token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{TagNaming: tt.naming, HeaderPrefix: tt.prefix}
+			if got := bindingTagName(field, tt.key, "", config); got != tt.want {
+				t.Fatalf("bindingTagName(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("rule_naming_wins", func(t *testing.T) {
+		config := &Config{TagNaming: map[string]NamingStrategy{"header": NamingProto}, HeaderPrefix: "X-"}
+		if got, want := bindingTagName(field, "header", NamingKebab, config), "X-header-token"; got != want {
+			t.Fatalf("bindingTagName = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestParseHeaderPrefix(t *testing.T) {
+	for _, prefix := range []string{"", "X-", "X-Acme-"} {
+		if _, err := ParseHeaderPrefix(prefix); err != nil {
+			t.Errorf("ParseHeaderPrefix(%q) unexpected error: %v", prefix, err)
+		}
+	}
+	for _, prefix := range []string{"X:", "X ", "X\"", "X,"} {
+		if _, err := ParseHeaderPrefix(prefix); err == nil {
+			t.Errorf("ParseHeaderPrefix(%q) expected error", prefix)
+		}
+	}
+}
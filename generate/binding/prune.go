@@ -0,0 +1,182 @@
+package binding
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/fatih/structtag"
+	"github.com/go-sphere/binding/sphere/binding"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// protocGenGoTagKeys are the tag keys protoc-gen-go emits itself. They are
+// never pruned; json is restored to its protoc-gen-go value instead.
+var protocGenGoTagKeys = map[string]bool{
+	"protobuf":       true,
+	"protobuf_key":   true,
+	"protobuf_val":   true,
+	"protobuf_oneof": true,
+	"json":           true,
+}
+
+// rewriteTags returns the tags that bring an existing .pb.go file for file up
+// to date, and whether the plugin generates any tag of its own for file. With
+// Config.PruneTags it adds the pruning of pruneTags, so that re-running against
+// previously retagged output converges to the result of retagging fresh
+// protoc-gen-go output.
+func rewriteTags(file *protogen.File, config *Config) (StructTags, bool, error) {
+	tags, err := extractFile(file, config)
+	if err != nil {
+		return nil, false, err
+	}
+	tagged := len(tags) > 0
+	if !config.PruneTags {
+		return tags, tagged, nil
+	}
+	if err = pruneTags(file.Messages, tags, ownedTagKeys(file, config)); err != nil {
+		return nil, false, err
+	}
+	return tags, tagged, nil
+}
+
+// ownedTagKeys returns the tag keys the plugin manages in the structs of file:
+// every built-in and custom binding location key and its aliases, the configured validation and
+// auto tags, the file and deprecated markers, and every auto or manual tag key named by
+// the rules or by the sphere.binding options of file. Keys that no longer
+// appear anywhere in the configuration or in file are not known to be owned
+// and are left alone.
+func ownedTagKeys(file *protogen.File, config *Config) []string {
+	owned := make(map[string]bool)
+	add := func(keys ...string) {
+		for _, key := range keys {
+			if !protocGenGoTagKeys[key] {
+				owned[key] = true
+			}
+		}
+	}
+
+	for _, key := range noJsonBinding {
+		add(key)
+		add(config.BindingAliases[key]...)
+	}
+	for _, custom := range config.CustomLocations {
+		add(custom.TagKey)
+		add(config.BindingAliases[custom.TagKey]...)
+	}
+	add(config.ValidationTags...)
+	add(config.DefaultAutoTags...)
+	add(config.ServiceAutoTags...)
+	add(fileMarkerKey, "deprecated")
+	if config.Rules != nil {
+		for _, rule := range config.Rules.Rules {
+			add(rule.AutoTags...)
+			add(manualTagKeys(rule.Tags)...)
+		}
+	}
+
+	var walk func(messages []*protogen.Message)
+	walk = func(messages []*protogen.Message) {
+		for _, message := range messages {
+			add(stringsExtension(message.Desc.Options(), binding.E_DefaultAutoTags)...)
+			for _, oneOf := range message.Oneofs {
+				add(stringsExtension(oneOf.Desc.Options(), binding.E_DefaultOneofAutoTags)...)
+			}
+			for _, field := range message.Fields {
+				add(stringsExtension(field.Desc.Options(), binding.E_AutoTags)...)
+				add(manualTagKeys(stringsExtension(field.Desc.Options(), binding.E_Tags))...)
+			}
+			walk(message.Messages)
+		}
+	}
+	walk(file.Messages)
+
+	keys := make([]string, 0, len(owned))
+	for key := range owned {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// manualTagKeys returns the keys set by manual tags entries, skipping removal
+// directives and entries that do not parse.
+func manualTagKeys(tags []string) []string {
+	var keys []string
+	for _, tag := range tags {
+		if strings.HasPrefix(tag, removeTagPrefix) {
+			continue
+		}
+		parsed, err := structtag.Parse(tag)
+		if err != nil {
+			continue
+		}
+		keys = append(keys, parsed.Keys()...)
+	}
+	return keys
+}
+
+// stringsExtension returns the value of the repeated string extension ext.
+func stringsExtension(options proto.Message, ext protoreflect.ExtensionType) []string {
+	if !proto.HasExtension(options, ext) {
+		return nil
+	}
+	return proto.GetExtension(options, ext).([]string)
+}
+
+// pruneTags adds to tags, for every field of the structs generated for
+// messages, a removal directive for each owned key. Keys the field is still
+// tagged with are thereby set afresh after the protoc-gen-go tags, in the same
+// order as on fresh output, and the others are removed. Fields outside oneofs
+// also get their protoc-gen-go json tag back unless the plugin sets or removes
+// json itself, undoing an earlier json:"-".
+func pruneTags(messages []*protogen.Message, tags StructTags, owned []string) error {
+	for _, message := range messages {
+		if message.Desc.IsMapEntry() {
+			continue
+		}
+		for _, field := range message.Fields {
+			structName, restoreJson := message.GoIdent.GoName, true
+			if field.Oneof != nil && !field.Oneof.Desc.IsSynthetic() {
+				// protoc-gen-go emits oneof members without a json tag.
+				structName, restoreJson = field.GoIdent.GoName, false
+			}
+			fieldTags := tags[structName][field.GoName]
+			if fieldTags == nil {
+				fieldTags = &structtag.Tags{}
+			}
+			if err := pruneFieldTags(fieldTags, owned, string(field.Desc.Name()), restoreJson); err != nil {
+				return err
+			}
+			if fieldTags.Len() == 0 {
+				continue
+			}
+			if tags[structName] == nil {
+				tags[structName] = make(map[string]*structtag.Tags)
+			}
+			tags[structName][field.GoName] = fieldTags
+		}
+		if err := pruneTags(message.Messages, tags, owned); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func pruneFieldTags(fieldTags *structtag.Tags, owned []string, name string, restoreJson bool) error {
+	for _, key := range owned {
+		if err := fieldTags.Set(&structtag.Tag{Key: removeTagPrefix + key}); err != nil {
+			return err
+		}
+	}
+	if !restoreJson {
+		return nil
+	}
+	for _, key := range []string{"json", removeTagPrefix + "json"} {
+		if _, err := fieldTags.Get(key); err == nil {
+			return nil
+		}
+	}
+	return setTag(fieldTags, "json", name, []string{"omitempty"})
+}
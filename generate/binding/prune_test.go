@@ -0,0 +1,115 @@
+package binding
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-sphere-binding/generate/internal/testutil"
+)
+
+func TestRewriteTags_Converges(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/basic.pb")
+	file := testutil.FileToGenerate(t, testutil.MustCreatePlugin(t, set, "basic.proto"))
+	fresh, err := os.ReadFile("testdata/gen/basic.pb.go")
+	if err != nil {
+		t.Fatalf("failed to read input fixture (run `make testdata`): %v", err)
+	}
+	retag := func(src []byte, cfg *Config) []byte {
+		t.Helper()
+		tags, _, err := rewriteTags(file, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, _, err := RetagSource("basic.pb.go", src, tags)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return out
+	}
+
+	keywordURI := DefaultConfig()
+	keywordURI.Rules = mustParseRules(t, `
+rules:
+  - match: testdata.basic.v1.BasicRequest.keyword
+    location: uri
+`)
+	keywordURIDB := DefaultConfig()
+	keywordURIDB.Rules = mustParseRules(t, `
+rules:
+  - match: testdata.basic.v1.BasicRequest.keyword
+    location: uri
+    auto_tags: [db]
+`)
+	keywordDB := DefaultConfig()
+	keywordDB.Rules = mustParseRules(t, `
+rules:
+  - match: testdata.basic.v1.BasicRequest.keyword
+    auto_tags: [db]
+`)
+	keepJson := DefaultConfig()
+	keepJson.AutoRemoveJson = false
+	keepJson.BindingAliases = map[string][]string{"query": {"form"}}
+	naming := DefaultConfig()
+	naming.TagNaming = map[string]NamingStrategy{"": NamingKebab}
+
+	tests := []struct {
+		name          string
+		first, second *Config
+	}{
+		{"location_change", keywordURI, DefaultConfig()},
+		{"auto_tags_kept_in_order", keywordURIDB, keywordDB},
+		{"json_restored", DefaultConfig(), keepJson},
+		{"json_removed_again", keepJson, DefaultConfig()},
+		{"naming_change", naming, DefaultConfig()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := retag(fresh, tt.second)
+			got := retag(retag(fresh, tt.first), tt.second)
+			if diff := firstDiff(string(want), string(got)); diff != "" {
+				t.Errorf("re-run does not converge:\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.PruneTags = false
+		got := retag(retag(fresh, keywordURI), cfg)
+		if !strings.Contains(string(got), `uri:"keyword"`) {
+			t.Errorf("expected the stale uri tag to be kept without pruning")
+		}
+	})
+}
+
+func TestOwnedTagKeys(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/tags.pb")
+	file := testutil.FileToGenerate(t, testutil.MustCreatePlugin(t, set, "tags.proto"))
+
+	cfg := DefaultConfig()
+	cfg.BindingAliases = map[string][]string{"uri": {"path"}}
+	cfg.ValidationTags = []string{"binding"}
+	owned := ownedTagKeys(file, cfg)
+
+	for _, key := range []string{"query", "uri", "form", "header", "path", "binding", "deprecated", "validate"} {
+		if !slices.Contains(owned, key) {
+			t.Errorf("expected %q to be owned, got %v", key, owned)
+		}
+	}
+	for _, key := range []string{"json", "protobuf", "protobuf_oneof"} {
+		if slices.Contains(owned, key) {
+			t.Errorf("protoc-gen-go key %q must not be owned", key)
+		}
+	}
+}
+
+func mustParseRules(t *testing.T, data string) *Rules {
+	t.Helper()
+	rules, err := ParseRules([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rules
+}
@@ -2,6 +2,7 @@ package binding
 
 import (
 	"bytes"
+	"fmt"
 	"go/ast"
 	"go/format"
 	"go/parser"
@@ -13,8 +14,50 @@ import (
 	"github.com/fatih/structtag"
 )
 
+// StructTags maps a struct name and field name to the tags the plugin sets on
+// that field. A tag whose key starts with removeTagPrefix is a removal
+// directive: the key after the prefix is deleted from the field instead. When
+// the key is also set, it is deleted first and then set again at the end of
+// the field's tag.
 type StructTags map[string]map[string]*structtag.Tags
 
+// removeTagPrefix marks removal directives in StructTags and in manual tags.
+const removeTagPrefix = "-"
+
+// TagChange describes a struct field whose tag is rewritten by the plugin.
+type TagChange struct {
+	Struct string
+	Field  string
+	Old    string
+	New    string
+}
+
+func (c TagChange) String() string {
+	return fmt.Sprintf("%s.%s: `%s` -> `%s`", c.Struct, c.Field, c.Old, c.New)
+}
+
+// UnappliedTag describes tags that matched nothing in the generated source:
+// either the whole struct is missing (Field is empty) or the struct exists but
+// has no field with that name.
+type UnappliedTag struct {
+	Struct string
+	Field  string
+}
+
+func (u UnappliedTag) String() string {
+	if u.Field == "" {
+		return fmt.Sprintf("struct %s not found", u.Struct)
+	}
+	return fmt.Sprintf("field %s.%s not found", u.Struct, u.Field)
+}
+
+// RetagReport lists what applying StructTags to a Go file did: the fields whose
+// tag changed and the tags that could not be applied at all.
+type RetagReport struct {
+	Changes   []TagChange
+	Unapplied []UnappliedTag
+}
+
 // RetagSource parses the Go source in src, applies tags to the matching struct
 // fields, and returns the gofmt-formatted result together with whether anything
 // actually changed. When no field was retagged it returns the original src
@@ -23,45 +66,73 @@ type StructTags map[string]map[string]*structtag.Tags
 // RetagSource performs no file I/O; filename is only used for error positions.
 // This makes it the natural seam for unit and golden tests.
 func RetagSource(filename string, src []byte, tags StructTags) ([]byte, bool, error) {
+	source, report, err := RetagSourceReport(filename, src, tags)
+	if err != nil {
+		return nil, false, err
+	}
+	return source, len(report.Changes) > 0, nil
+}
+
+// RetagSourceReport is RetagSource returning the full RetagReport instead of a
+// changed flag.
+func RetagSourceReport(filename string, src []byte, tags StructTags) ([]byte, *RetagReport, error) {
 	fs := token.NewFileSet()
 	fn, err := parser.ParseFile(fs, filename, src, parser.ParseComments)
 	if err != nil {
-		return nil, false, err
+		return nil, nil, err
 	}
 
-	changed := false
-	if err := ReTagsWithCheck(fn, tags, &changed); err != nil {
-		return nil, false, err
+	report, err := reTagsInternal(fn, tags)
+	if err != nil {
+		return nil, nil, err
 	}
-	if !changed {
-		return src, false, nil
+	if len(report.Changes) == 0 {
+		return src, report, nil
 	}
 
 	var buf bytes.Buffer
 	if err := printer.Fprint(&buf, fs, fn); err != nil {
-		return nil, false, err
+		return nil, nil, err
 	}
 
 	source, err := format.Source(buf.Bytes())
 	if err != nil {
-		return nil, false, err
+		return nil, nil, err
+	}
+	return source, report, nil
+}
+
+// PlanRetag parses the Go source in src and reports what applying tags would
+// do, without producing the rewritten source.
+func PlanRetag(filename string, src []byte, tags StructTags) (*RetagReport, error) {
+	fs := token.NewFileSet()
+	fn, err := parser.ParseFile(fs, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
 	}
-	return source, true, nil
+	return reTagsInternal(fn, tags)
 }
 
 // ReTagsWithCheck modifies tags and detects actual changes
 func ReTagsWithCheck(file *ast.File, tags StructTags, changed *bool) error {
+	report, err := reTagsInternal(file, tags)
 	if changed != nil {
-		*changed = false
+		*changed = err == nil && len(report.Changes) > 0
 	}
-	return reTagsInternal(file, tags, changed)
+	return err
 }
 
 func ReTags(file *ast.File, tags StructTags) error {
-	return reTagsInternal(file, tags, nil)
+	_, err := reTagsInternal(file, tags)
+	return err
 }
 
-func reTagsInternal(file *ast.File, tags StructTags, changed *bool) error {
+// reTagsInternal applies tags to the matching struct fields in file and reports
+// the fields whose tag value actually changed plus the tags that matched no
+// struct field.
+func reTagsInternal(file *ast.File, tags StructTags) (*RetagReport, error) {
+	report := &RetagReport{}
+	applied := make(map[string]map[string]bool, len(tags))
 	for _, decl := range file.Decls {
 		genDecl, ok := decl.(*ast.GenDecl)
 		if !ok {
@@ -84,6 +155,9 @@ func reTagsInternal(file *ast.File, tags StructTags, changed *bool) error {
 			if !structFound {
 				continue
 			}
+			if applied[structName] == nil {
+				applied[structName] = make(map[string]bool)
+			}
 
 			for _, field := range structDecl.Fields.List {
 				for _, fieldName := range field.Names {
@@ -91,6 +165,7 @@ func reTagsInternal(file *ast.File, tags StructTags, changed *bool) error {
 					if !fieldFound || newTags == nil {
 						continue
 					}
+					applied[structName][fieldName.String()] = true
 
 					if field.Tag == nil {
 						field.Tag = &ast.BasicLit{Kind: token.STRING}
@@ -99,27 +174,67 @@ func reTagsInternal(file *ast.File, tags StructTags, changed *bool) error {
 					currentTagValue := strings.Trim(field.Tag.Value, "`")
 					oldTags, parseErr := structtag.Parse(currentTagValue)
 					if parseErr != nil {
-						return parseErr
+						return nil, parseErr
 					}
 
 					originalTagValue := oldTags.String()
 
 					sort.Stable(newTags)
 					for _, t := range newTags.Tags() {
+						if key, remove := strings.CutPrefix(t.Key, removeTagPrefix); remove {
+							oldTags.Delete(key)
+							continue
+						}
 						if setErr := oldTags.Set(t); setErr != nil {
-							return setErr
+							return nil, setErr
 						}
 					}
 					newTagValue := oldTags.String()
 
-					if changed != nil && originalTagValue != newTagValue {
-						*changed = true
+					if originalTagValue != newTagValue {
+						report.Changes = append(report.Changes, TagChange{
+							Struct: structName,
+							Field:  fieldName.String(),
+							Old:    originalTagValue,
+							New:    newTagValue,
+						})
 					}
 
+					if newTagValue == "" {
+						// Every key was removed.
+						field.Tag = nil
+						continue
+					}
 					field.Tag.Value = "`" + newTagValue + "`"
 				}
 			}
 		}
 	}
-	return nil
+	report.Unapplied = unappliedTags(tags, applied)
+	return report, nil
+}
+
+// unappliedTags lists the entries of tags that are not marked in applied,
+// sorted so reports are deterministic.
+func unappliedTags(tags StructTags, applied map[string]map[string]bool) []UnappliedTag {
+	var unapplied []UnappliedTag
+	for structName, fields := range tags {
+		appliedFields, structFound := applied[structName]
+		if !structFound {
+			unapplied = append(unapplied, UnappliedTag{Struct: structName})
+			continue
+		}
+		for fieldName, fieldTags := range fields {
+			if fieldTags != nil && !appliedFields[fieldName] {
+				unapplied = append(unapplied, UnappliedTag{Struct: structName, Field: fieldName})
+			}
+		}
+	}
+	sort.Slice(unapplied, func(i, j int) bool {
+		if unapplied[i].Struct != unapplied[j].Struct {
+			return unapplied[i].Struct < unapplied[j].Struct
+		}
+		return unapplied[i].Field < unapplied[j].Field
+	})
+	return unapplied
 }
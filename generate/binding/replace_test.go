@@ -1,6 +1,7 @@
 package binding
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 
@@ -108,3 +109,60 @@ func TestReTagsWithCheckChangedFlag(t *testing.T) {
 		}
 	})
 }
+
+func TestRetagSourceReportUnapplied(t *testing.T) {
+	tags := StructTags{
+		"Foo":     {"Name": mustTags(t, `query:"name"`), "Missing": mustTags(t, `query:"missing"`)},
+		"NoSuch":  {"Field": mustTags(t, `query:"field"`)},
+		"Another": {"X": mustTags(t, `query:"x"`)},
+	}
+	_, report, err := RetagSourceReport("foo.go", []byte(retagSrc), tags)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Changes) != 1 || report.Changes[0].Struct != "Foo" || report.Changes[0].Field != "Name" {
+		t.Fatalf("Changes = %v, want only Foo.Name", report.Changes)
+	}
+	want := []UnappliedTag{
+		{Struct: "Another"},
+		{Struct: "Foo", Field: "Missing"},
+		{Struct: "NoSuch"},
+	}
+	if !reflect.DeepEqual(report.Unapplied, want) {
+		t.Fatalf("Unapplied = %v, want %v", report.Unapplied, want)
+	}
+}
+
+func TestRetagSourceRemoval(t *testing.T) {
+	removal := func(keys ...string) *structtag.Tags {
+		tags := &structtag.Tags{}
+		for _, key := range keys {
+			if err := removeTag(tags, key); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return tags
+	}
+	nameTags := removal("json")
+	if err := nameTags.Set(&structtag.Tag{Key: "query", Name: "name"}); err != nil {
+		t.Fatal(err)
+	}
+	tags := StructTags{
+		"Foo": {"Name": nameTags, "Age": removal("json")},
+		"Bar": {"ID": removal("db")},
+	}
+	out, report, err := RetagSourceReport("foo.go", []byte(retagSrc), tags)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(out)
+	for _, want := range []string{"Name string `query:\"name\"`", "Age  int\n", "ID string `json:\"id,omitempty\"`"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+	// Removing a key the field does not have is not a change.
+	if len(report.Changes) != 2 {
+		t.Fatalf("Changes = %v, want Foo.Name and Foo.Age", report.Changes)
+	}
+}
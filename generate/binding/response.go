@@ -0,0 +1,158 @@
+package binding
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	gengo "google.golang.org/protobuf/cmd/protoc-gen-go/internal_gengo"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// GenerateResponse regenerates the .pb.go files for gen with the protoc-gen-go
+// binary at protocGenGo, re-tags them in memory and emits the result through
+// the plugin response instead of rewriting files on disk. It lets the plugin
+// replace protoc-gen-go in a regular buf.gen.yaml, where plugins do not share
+// an output directory.
+func GenerateResponse(gen *protogen.Plugin, protocGenGo string, config *Config) error {
+	files, err := runProtocGenGo(protocGenGo, gen.Request)
+	if err != nil {
+		return err
+	}
+	return emitRetaggedFiles(gen, files, config)
+}
+
+// GenerateCombined runs the protoc-gen-go generator in-process for every file
+// in gen, re-tags the generated source in memory and emits the result through
+// the plugin response, so a single plugin invocation yields the final .pb.go
+// files without a separate protoc-gen-go binary.
+func GenerateCombined(gen *protogen.Plugin, config *Config) error {
+	gen.SupportedFeatures = gengo.SupportedFeatures
+	gen.SupportedEditionsMinimum = gengo.SupportedEditionsMinimum
+	gen.SupportedEditionsMaximum = gengo.SupportedEditionsMaximum
+
+	for _, file := range gen.Files {
+		if !file.Generate {
+			continue
+		}
+		generated := gengo.GenerateFile(gen, file)
+
+		tags, err := extractFile(file, config)
+		if err != nil {
+			return err
+		}
+		if len(tags) == 0 {
+			continue
+		}
+
+		content, err := generated.Content()
+		if err != nil {
+			return err
+		}
+		filename := file.GeneratedFilenamePrefix + ".pb.go"
+		source, report, err := RetagSourceReport(filename, content, tags)
+		if err != nil {
+			return err
+		}
+		if err = reportUnapplied(file, filename, report.Unapplied, config); err != nil {
+			return err
+		}
+		if len(report.Changes) == 0 {
+			continue
+		}
+
+		// Replace the protoc-gen-go output with the retagged source.
+		generated.Skip()
+		if _, err = gen.NewGeneratedFile(filename, "").Write(source); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runProtocGenGo feeds req to the protoc-gen-go binary at path and returns the
+// files it generated. Only the parameters protoc-gen-go understands are
+// forwarded; the binding parameters would make it fail.
+func runProtocGenGo(path string, req *pluginpb.CodeGeneratorRequest) ([]*pluginpb.CodeGeneratorResponse_File, error) {
+	forwarded := proto.Clone(req).(*pluginpb.CodeGeneratorRequest)
+	forwarded.Parameter = proto.String(protocGenGoParameter(req.GetParameter()))
+
+	in, err := proto.Marshal(forwarded)
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(path)
+	cmd.Stdin = bytes.NewReader(in)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err = cmd.Run(); err != nil {
+		return nil, fmt.Errorf("run %s: %w: %s", path, err, strings.TrimSpace(stderr.String()))
+	}
+
+	var resp pluginpb.CodeGeneratorResponse
+	if err = proto.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("decode %s response: %w", path, err)
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("%s: %s", path, resp.GetError())
+	}
+	return resp.File, nil
+}
+
+// protocGenGoParameter keeps the entries of a comma-separated plugin parameter
+// string that protoc-gen-go accepts and drops everything else.
+func protocGenGoParameter(param string) string {
+	var kept []string
+	for _, entry := range strings.Split(param, ",") {
+		key, _, _ := strings.Cut(entry, "=")
+		switch {
+		case key == "module", key == "paths", key == "annotate_code", key == "default_api_level":
+		case strings.HasPrefix(key, "M"), strings.HasPrefix(key, "apilevelM"):
+		default:
+			continue
+		}
+		kept = append(kept, entry)
+	}
+	return strings.Join(kept, ",")
+}
+
+// emitRetaggedFiles re-tags the protoc-gen-go output files and adds them to the
+// plugin response. Files that do not belong to a proto being generated, such as
+// annotation metadata, are passed through untouched.
+func emitRetaggedFiles(gen *protogen.Plugin, files []*pluginpb.CodeGeneratorResponse_File, config *Config) error {
+	byName := make(map[string]*protogen.File)
+	for _, f := range gen.Files {
+		if f.Generate {
+			byName[f.GeneratedFilenamePrefix+".pb.go"] = f
+		}
+	}
+
+	for _, generated := range files {
+		if generated.GetInsertionPoint() != "" {
+			return fmt.Errorf("%s: insertion points are not supported", generated.GetName())
+		}
+		content := []byte(generated.GetContent())
+		if file, ok := byName[generated.GetName()]; ok {
+			tags, err := extractFile(file, config)
+			if err != nil {
+				return err
+			}
+			var report *RetagReport
+			if content, report, err = RetagSourceReport(generated.GetName(), content, tags); err != nil {
+				return err
+			}
+			if err = reportUnapplied(file, generated.GetName(), report.Unapplied, config); err != nil {
+				return err
+			}
+		}
+		if _, err := gen.NewGeneratedFile(generated.GetName(), "").Write(content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
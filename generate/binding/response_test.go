@@ -0,0 +1,108 @@
+package binding
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-sphere-binding/generate/internal/testutil"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+func TestProtocGenGoParameter(t *testing.T) {
+	tests := []struct {
+		name  string
+		param string
+		want  string
+	}{
+		{"empty", "", ""},
+		{"keeps protoc-gen-go parameters", "paths=source_relative,module=example.com/api", "paths=source_relative,module=example.com/api"},
+		{"keeps import mappings", "Mfoo.proto=example.com/foo,apilevelMfoo.proto=API_OPAQUE", "Mfoo.proto=example.com/foo,apilevelMfoo.proto=API_OPAQUE"},
+		{"drops binding parameters", "paths=source_relative,mode=response,out=api,auto_remove_json=false", "paths=source_relative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := protocGenGoParameter(tt.param); got != tt.want {
+				t.Fatalf("protocGenGoParameter(%q) = %q, want %q", tt.param, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestEmitRetaggedFiles feeds the committed protoc-gen-go output through the
+// response path and expects the emitted file to match the rewrite-mode golden
+// file, while unrelated files pass through untouched.
+func TestEmitRetaggedFiles(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/basic.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "basic.proto")
+	file := testutil.FileToGenerate(t, plugin)
+
+	input, err := os.ReadFile("testdata/gen/basic.pb.go")
+	if err != nil {
+		t.Fatalf("read input fixture (run `make testdata`): %v", err)
+	}
+	name := file.GeneratedFilenamePrefix + ".pb.go"
+	files := []*pluginpb.CodeGeneratorResponse_File{
+		{Name: proto.String(name), Content: proto.String(string(input))},
+		{Name: proto.String("extra.txt"), Content: proto.String("untouched\n")},
+	}
+	if err = emitRetaggedFiles(plugin, files, DefaultConfig()); err != nil {
+		t.Fatalf("emitRetaggedFiles failed: %v", err)
+	}
+
+	resp := plugin.Response()
+	if resp.Error != nil {
+		t.Fatalf("plugin response error: %s", resp.GetError())
+	}
+	got := make(map[string]string)
+	for _, f := range resp.File {
+		got[f.GetName()] = f.GetContent()
+	}
+
+	want, err := os.ReadFile("testdata/golden/basic.pb.go")
+	if err != nil {
+		t.Fatalf("read golden (run `make update-golden`): %v", err)
+	}
+	if diff := firstDiff(string(want), got[name]); diff != "" {
+		t.Errorf("emitted %s differs from golden:\n%s", name, diff)
+	}
+	if got["extra.txt"] != "untouched\n" {
+		t.Errorf("extra.txt = %q, want it passed through untouched", got["extra.txt"])
+	}
+}
+
+// TestGenerateCombined runs the embedded protoc-gen-go generator and expects
+// the emitted file to match the golden file below the version header, which
+// differs only because the test pins a compiler version.
+func TestGenerateCombined(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/oneof.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "oneof.proto")
+
+	if err := GenerateCombined(plugin, DefaultConfig()); err != nil {
+		t.Fatalf("GenerateCombined failed: %v", err)
+	}
+	resp := plugin.Response()
+	if resp.Error != nil {
+		t.Fatalf("plugin response error: %s", resp.GetError())
+	}
+	if len(resp.File) != 1 {
+		t.Fatalf("expected exactly one generated file, got %d", len(resp.File))
+	}
+
+	want, err := os.ReadFile("testdata/golden/oneof.pb.go")
+	if err != nil {
+		t.Fatalf("read golden (run `make update-golden`): %v", err)
+	}
+	if diff := firstDiff(stripHeader(string(want)), stripHeader(resp.File[0].GetContent())); diff != "" {
+		t.Errorf("combined output differs from golden:\n%s", diff)
+	}
+}
+
+// stripHeader drops everything before the package clause of a generated file.
+func stripHeader(src string) string {
+	if i := strings.Index(src, "\npackage "); i >= 0 {
+		return src[i:]
+	}
+	return src
+}
@@ -0,0 +1,171 @@
+package binding
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/fatih/structtag"
+	"github.com/go-sphere/binding/sphere/binding"
+	"go.yaml.in/yaml/v3"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Rules are tagging rules declared outside the proto files, for protos that
+// cannot be annotated. Each rule matches fully-qualified message or field names
+// with a glob and supplies the same settings as the sphere.binding options.
+type Rules struct {
+	Rules []*Rule `yaml:"rules"`
+}
+
+// Rule is a single entry of a rules file. Match is a glob over fully-qualified
+// names where "*" matches within one name segment, "**" matches across
+// segments and "?" matches one character, e.g. "acme.v1.*Request.page_*".
+type Rule struct {
+	Match      string   `yaml:"match"`
+	Location   string   `yaml:"location"`
+	AutoTags   []string `yaml:"auto_tags"`
+	Tags       []string `yaml:"tags"`
+	RemoveTags []string `yaml:"remove_tags"`
+	Naming     string   `yaml:"naming"`
+
+	pattern  *regexp.Regexp
+	location binding.BindingLocation
+}
+
+// LoadRules reads and validates the YAML rules file at path.
+func LoadRules(path string) (*Rules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := ParseRules(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return rules, nil
+}
+
+// ParseRules parses and validates YAML rules. Unknown keys are rejected so
+// typos do not silently disable a rule.
+func ParseRules(data []byte) (*Rules, error) {
+	rules := &Rules{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(rules); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	for i, rule := range rules.Rules {
+		if err := rule.compile(); err != nil {
+			return nil, fmt.Errorf("rules[%d]: %w", i, err)
+		}
+	}
+	return rules, nil
+}
+
+func (r *Rule) compile() error {
+	if r.Match == "" {
+		return fmt.Errorf("match is required")
+	}
+	pattern, err := compileGlob(r.Match)
+	if err != nil {
+		return fmt.Errorf("invalid match '%s': %w", r.Match, err)
+	}
+	r.pattern = pattern
+
+	if r.location, err = ParseBindingLocation(r.Location); err != nil {
+		return err
+	}
+	for _, key := range r.AutoTags {
+		if err = ValidateTagKey(key); err != nil {
+			return fmt.Errorf("invalid auto tag: %w", err)
+		}
+	}
+	for _, key := range r.RemoveTags {
+		if err = validateRemovedTagKey(key); err != nil {
+			return fmt.Errorf("invalid tag removal: %w", err)
+		}
+	}
+	for _, tag := range r.Tags {
+		if name, ok := strings.CutPrefix(tag, locationDirectivePrefix); ok {
+			// Custom locations are only known once the config is built.
+			if err = ValidateTagKey(name); err != nil {
+				return fmt.Errorf("invalid location directive '%s': %w", tag, err)
+			}
+			continue
+		}
+		if _, err = structtag.Parse(tag); err != nil {
+			return fmt.Errorf("invalid tag '%s': %w", tag, err)
+		}
+	}
+	if r.Naming != "" && !namingStrategies[NamingStrategy(r.Naming)] {
+		return fmt.Errorf("unknown naming strategy '%s'", r.Naming)
+	}
+	return nil
+}
+
+// compileGlob turns a fully-qualified name glob into an anchored regexp.
+func compileGlob(glob string) (*regexp.Regexp, error) {
+	var expr strings.Builder
+	expr.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				expr.WriteString(".*")
+				i++
+			} else {
+				expr.WriteString(`[^.]*`)
+			}
+		case '?':
+			expr.WriteString(`[^.]`)
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	expr.WriteString("$")
+	return regexp.Compile(expr.String())
+}
+
+// ruleSettings are the settings of every rule matching one name, merged in
+// file order: later rules override the location, auto tags and naming of
+// earlier ones, while tags and tag removals accumulate.
+type ruleSettings struct {
+	matched     bool
+	location    binding.BindingLocation
+	autoTags    []string
+	hasAutoTags bool
+	tags        []string
+	removeTags  []string
+	naming      NamingStrategy
+}
+
+// match merges the rules matching name. It is safe to call on nil Rules.
+func (r *Rules) match(name protoreflect.FullName) ruleSettings {
+	var settings ruleSettings
+	if r == nil {
+		return settings
+	}
+	for _, rule := range r.Rules {
+		if !rule.pattern.MatchString(string(name)) {
+			continue
+		}
+		settings.matched = true
+		if rule.location != binding.BindingLocation_BINDING_LOCATION_UNSPECIFIED {
+			settings.location = rule.location
+		}
+		if rule.AutoTags != nil {
+			settings.autoTags, settings.hasAutoTags = rule.AutoTags, true
+		}
+		if rule.Naming != "" {
+			settings.naming = NamingStrategy(rule.Naming)
+		}
+		settings.tags = append(settings.tags, rule.Tags...)
+		settings.removeTags = append(settings.removeTags, rule.RemoveTags...)
+	}
+	return settings
+}
@@ -0,0 +1,179 @@
+package binding
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-sphere/binding/sphere/binding"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// mustLoadRules loads a rules file for golden cases, whose config functions
+// cannot report errors.
+func mustLoadRules(path string) *Rules {
+	rules, err := LoadRules(path)
+	if err != nil {
+		panic(err)
+	}
+	return rules
+}
+
+func TestCompileGlob(t *testing.T) {
+	tests := []struct {
+		glob  string
+		name  string
+		match bool
+	}{
+		{"acme.v1.*Request", "acme.v1.ListRequest", true},
+		{"acme.v1.*Request", "acme.v1.ListRequest.page", false},
+		{"acme.v1.*Request.page_*", "acme.v1.ListRequest.page_size", true},
+		{"acme.v1.*Request.page_*", "acme.v1.ListResponse.page_size", false},
+		{"acme.**.id", "acme.v1.User.Address.id", true},
+		{"acme.v?.User", "acme.v2.User", true},
+		{"acme.v?.User", "acme.v10.User", false},
+		{"acme.v1.User+", "acme.v1.User+", true},
+	}
+	for _, tt := range tests {
+		pattern, err := compileGlob(tt.glob)
+		if err != nil {
+			t.Fatalf("compileGlob(%q) failed: %v", tt.glob, err)
+		}
+		if got := pattern.MatchString(tt.name); got != tt.match {
+			t.Errorf("glob %q matching %q = %v, want %v", tt.glob, tt.name, got, tt.match)
+		}
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules([]byte(`
+rules:
+  - match: acme.v1.*Request.*
+    location: query
+    auto_tags: [validate]
+    naming: camel
+  - match: acme.v1.ListRequest.page_token
+    location: header
+    auto_tags: []
+    tags: ['query:"cursor"']
+  - match: acme.v1.ListRequest.page_token
+    tags: ['form:"cursor"']
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := rules.match(protoreflect.FullName("acme.v1.ListRequest.page_token"))
+	want := ruleSettings{
+		matched:     true,
+		location:    binding.BindingLocation_BINDING_LOCATION_HEADER,
+		autoTags:    []string{},
+		hasAutoTags: true,
+		tags:        []string{`query:"cursor"`, `form:"cursor"`},
+		naming:      NamingCamel,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("match = %+v, want %+v", got, want)
+	}
+	if got := rules.match("acme.v1.ListResponse.page_token"); !reflect.DeepEqual(got, ruleSettings{}) {
+		t.Fatalf("unmatched name got settings %+v", got)
+	}
+	var none *Rules
+	if got := none.match("acme.v1.ListRequest"); !reflect.DeepEqual(got, ruleSettings{}) {
+		t.Fatalf("nil rules got settings %+v", got)
+	}
+}
+
+func TestParseRulesErrors(t *testing.T) {
+	tests := map[string]string{
+		"unknown key":         "rules:\n  - match: a.B\n    locaton: query\n",
+		"missing match":       "rules:\n  - location: query\n",
+		"unknown location":    "rules:\n  - match: a.B\n    location: body\n",
+		"invalid auto tag":    "rules:\n  - match: a.B\n    auto_tags: ['a b']\n",
+		"invalid tag":         "rules:\n  - match: a.B\n    tags: ['query']\n",
+		"unknown naming":      "rules:\n  - match: a.B\n    naming: snake\n",
+		"runtime tag removal": "rules:\n  - match: a.B\n    remove_tags: [protobuf_oneof]\n",
+		"invalid yaml type":   "rules: {}\n",
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseRules([]byte(data)); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+	if _, err := ParseRules(nil); err != nil {
+		t.Fatalf("empty rules file should be valid, got %v", err)
+	}
+}
+
+func TestExtractFile_RulesPrecedence(t *testing.T) {
+	// The field rule loses against the explicit HEADER option on shelf, but
+	// wins over the location inferred from the path template for name.
+	rules, err := ParseRules([]byte(`
+rules:
+  - match: api.v1.GetBookRequest
+    location: form
+  - match: api.v1.GetBookRequest.name
+    location: query
+  - match: api.v1.GetBookRequest.shelf
+    location: query
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := DefaultConfig()
+	cfg.InferURILocations = true
+	cfg.Rules = rules
+	tags, err := extractFile(httpTestFile(t, encodeHTTPRule(httpRuleGet, "/v1/books/{name}", "")), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"Name":  `query:"name" json:"-"`,
+		"Shelf": `header:"Shelf" json:"-"`,
+		"Page":  `form:"page" json:"-"`,
+	}
+	for field, value := range want {
+		if got := tags["GetBookRequest"][field]; got == nil || got.String() != value {
+			t.Errorf("GetBookRequest.%s tags = %v, want %q", field, got, value)
+		}
+	}
+	if !strings.Contains(tags["GetBookRequest_Book"]["Id"].String(), `form:"id"`) {
+		t.Errorf("nested message should inherit the message rule, got %v", tags["GetBookRequest_Book"])
+	}
+}
+
+func TestExtractFile_RulesNaming(t *testing.T) {
+	// The message rule's naming applies to its fields and nested messages,
+	// and a field rule overrides it.
+	cfg := DefaultConfig()
+	cfg.Rules = mustParseRules(t, `
+rules:
+  - match: api.v1.GetBookRequest
+    location: query
+    naming: header_canonical
+  - match: api.v1.GetBookRequest.name
+    naming: proto
+`)
+	tags, err := extractFile(httpTestFile(t, encodeHTTPRule(httpRuleGet, "/v1/books", "")), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]map[string]string{
+		"GetBookRequest": {
+			"Name": `query:"name" json:"-"`,
+			"Page": `query:"Page" json:"-"`,
+		},
+		"GetBookRequest_Book": {
+			"Id": `query:"Id" json:"-"`,
+		},
+	}
+	for message, fields := range want {
+		for field, value := range fields {
+			if got := tags[message][field]; got == nil || got.String() != value {
+				t.Errorf("%s.%s tags = %v, want %q", message, field, got, value)
+			}
+		}
+	}
+}
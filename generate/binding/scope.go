@@ -0,0 +1,153 @@
+package binding
+
+import (
+	"slices"
+
+	"github.com/go-sphere/binding/sphere/binding"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Annotation levels that can decide the location or auto tags of a field,
+// from the least to the most specific.
+const (
+	// LevelDefault means nothing set the value: the field binds to JSON and
+	// gets no auto tags.
+	LevelDefault = "default"
+	// LevelFile is the default_location and default_auto_tags parameters.
+	LevelFile = "file"
+	// LevelService is the service_auto_tags parameter.
+	LevelService = "service"
+	// LevelMethod is the method_locations parameter.
+	LevelMethod = "method"
+	// LevelMessageRule and LevelMessage are a rule matching the message, or
+	// an enclosing one, and the message options.
+	LevelMessageRule = "message_rule"
+	LevelMessage     = "message"
+	// LevelOneofRule and LevelOneof are a rule matching the oneof and the
+	// oneof options.
+	LevelOneofRule = "oneof_rule"
+	LevelOneof     = "oneof"
+	// LevelInferred is a location inferred from google.api.http. Only path
+	// variables are applied over a location set by LevelMessage or LevelOneof.
+	LevelInferred = "inferred"
+	// LevelFieldRule and LevelField are a rule matching the field and the
+	// field options, including location directives.
+	LevelFieldRule = "field_rule"
+	LevelField     = "field"
+)
+
+// BindingSource names the annotation level that set a location or auto tags
+// and the declaration it was set on: a proto file path, or the full name of a
+// service, method, message, oneof or field.
+type BindingSource struct {
+	Level string `json:"level"`
+	Name  string `json:"name,omitempty"`
+}
+
+// bindingScope is the location, auto tags and rule naming in effect at one
+// point of the file → message → nested message → oneof → field chain, together
+// with the sources that set them and the trace of the declarations consulted
+// so far.
+type bindingScope struct {
+	location       binding.BindingLocation
+	autoTags       []string
+	naming         NamingStrategy
+	locationSource BindingSource
+	autoTagsSource BindingSource
+	trace          []scopeStep
+}
+
+// scopeStep is one declaration consulted while resolving a scope and what it
+// set, if anything: via names the parameter, rules file or option the value
+// came from.
+type scopeStep struct {
+	source       BindingSource
+	via          string
+	location     binding.BindingLocation
+	autoTags     []string
+	setsLocation bool
+	setsAutoTags bool
+}
+
+// defaultScope is the scope before any annotation applies.
+func defaultScope() bindingScope {
+	return bindingScope{
+		locationSource: BindingSource{Level: LevelDefault},
+		autoTagsSource: BindingSource{Level: LevelDefault},
+	}
+}
+
+func (s bindingScope) setLocation(location binding.BindingLocation, level, name, via string) bindingScope {
+	s.location = location
+	s.locationSource = BindingSource{Level: level, Name: name}
+	return s.record(scopeStep{source: s.locationSource, via: via, location: location, setsLocation: true})
+}
+
+func (s bindingScope) setAutoTags(autoTags []string, level, name, via string) bindingScope {
+	s.autoTags = autoTags
+	s.autoTagsSource = BindingSource{Level: level, Name: name}
+	return s.record(scopeStep{source: s.autoTagsSource, via: via, autoTags: autoTags, setsAutoTags: true})
+}
+
+// locationSetAt reports whether a declaration of one of levels set the
+// location of s.
+func (s bindingScope) locationSetAt(levels ...string) bool {
+	return slices.ContainsFunc(s.trace, func(step scopeStep) bool {
+		return step.setsLocation && slices.Contains(levels, step.source.Level)
+	})
+}
+
+// record appends step to the trace. Scopes are copied down the chain, so the
+// trace is clipped first to keep siblings from sharing a backing array.
+func (s bindingScope) record(step scopeStep) bindingScope {
+	s.trace = append(slices.Clip(s.trace), step)
+	return s
+}
+
+// withRules applies the location, auto tags and naming of the rules matching
+// name.
+func (s bindingScope) withRules(rules ruleSettings, level string, name protoreflect.FullName) bindingScope {
+	if rules.naming != "" {
+		s.naming = rules.naming
+	}
+	start := len(s.trace)
+	if rules.location != binding.BindingLocation_BINDING_LOCATION_UNSPECIFIED {
+		s = s.setLocation(rules.location, level, string(name), "rules_file")
+	}
+	if rules.hasAutoTags {
+		s = s.setAutoTags(rules.autoTags, level, string(name), "rules_file")
+	}
+	if rules.matched && len(s.trace) == start {
+		s = s.record(scopeStep{source: BindingSource{Level: level, Name: string(name)}, via: "rules_file"})
+	}
+	return s
+}
+
+// withOptions applies the location and auto tags extensions set in the
+// options of the declaration name.
+func (s bindingScope) withOptions(
+	options proto.Message,
+	locationExt protoreflect.ExtensionType,
+	autoTagsExt protoreflect.ExtensionType,
+	level string,
+	name protoreflect.FullName,
+) bindingScope {
+	start := len(s.trace)
+	if proto.HasExtension(options, locationExt) {
+		location := proto.GetExtension(options, locationExt).(binding.BindingLocation)
+		s = s.setLocation(location, level, string(name), extensionName(locationExt))
+	}
+	if proto.HasExtension(options, autoTagsExt) {
+		autoTags := proto.GetExtension(options, autoTagsExt).([]string)
+		s = s.setAutoTags(autoTags, level, string(name), extensionName(autoTagsExt))
+	}
+	if len(s.trace) == start {
+		s = s.record(scopeStep{source: BindingSource{Level: level, Name: string(name)}})
+	}
+	return s
+}
+
+func extensionName(ext protoreflect.ExtensionType) string {
+	return string(ext.TypeDescriptor().FullName())
+}
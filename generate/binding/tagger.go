@@ -3,6 +3,8 @@ package binding
 import (
 	"fmt"
 	"maps"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/fatih/structtag"
@@ -10,18 +12,117 @@ import (
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
 )
 
+// Binding locations the plugin adds to the upstream BindingLocation enum.
+// Proto options cannot select them; plugin parameters and rules files use
+// their names, "file" and "cookie".
+const (
+	// BindingLocationFile binds a multipart file upload. Fields get a form
+	// tag plus the fileMarkerKey marker, e.g. `form:"avatar" file:"true"`.
+	BindingLocationFile binding.BindingLocation = 100 + iota
+	// BindingLocationCookie binds a request cookie, e.g. `cookie:"session_id"`.
+	BindingLocationCookie
+)
+
+// fileMarkerKey marks fields bound to BindingLocationFile, so handlers know to
+// read a multipart.FileHeader instead of a form value.
+const fileMarkerKey = "file"
+
 var noJsonBinding = map[binding.BindingLocation]string{
 	binding.BindingLocation_BINDING_LOCATION_QUERY:  "query",
 	binding.BindingLocation_BINDING_LOCATION_URI:    "uri",
 	binding.BindingLocation_BINDING_LOCATION_FORM:   "form",
 	binding.BindingLocation_BINDING_LOCATION_HEADER: "header",
+	BindingLocationFile:                             "form",
+	BindingLocationCookie:                           "cookie",
+}
+
+// DeprecatedMode controls how fields marked deprecated = true are tagged.
+type DeprecatedMode string
+
+const (
+	// DeprecatedTag tags deprecated fields like any other field.
+	DeprecatedTag DeprecatedMode = "tag"
+	// DeprecatedSkip leaves deprecated fields without generated tags; manual
+	// tags still apply.
+	DeprecatedSkip DeprecatedMode = "skip"
+	// DeprecatedMark tags deprecated fields and adds a deprecated:"true" tag.
+	DeprecatedMark DeprecatedMode = "mark"
+)
+
+// ParseDeprecatedMode validates a deprecated_fields value; empty means
+// DeprecatedTag.
+func ParseDeprecatedMode(mode string) (DeprecatedMode, error) {
+	switch DeprecatedMode(mode) {
+	case "", DeprecatedTag:
+		return DeprecatedTag, nil
+	case DeprecatedSkip, DeprecatedMark:
+		return DeprecatedMode(mode), nil
+	default:
+		return "", fmt.Errorf("invalid deprecated fields mode '%s': want 'tag', 'skip' or 'mark'", mode)
+	}
 }
 
 type Config struct {
 	AutoRemoveJson bool
 	BindingAliases map[string][]string
+	// InferURILocations binds fields referenced by google.api.http path
+	// template variables to the URI location unless they set one explicitly.
+	InferURILocations bool
+	// InferHTTPLocations extends InferURILocations to the whole google.api.http
+	// rule: the body field (or every field for "*") binds to JSON and the
+	// remaining request fields to the query string.
+	InferHTTPLocations bool
+	// ServiceFiles are the files, e.g. every file of the plugin request, whose
+	// services infer locations for the messages of the file being tagged, so
+	// that request messages declared apart from their service are covered. The
+	// services of the file itself are always used.
+	ServiceFiles []*protogen.File
+	// Strict turns tags that match no generated struct field into an error
+	// instead of a warning.
+	Strict bool
+	// TagNaming selects how binding location tag values are derived from the
+	// field name, keyed by location tag key; the "" key is the default.
+	// Header tags default to NamingHeaderCanonical unless "header" is set.
+	TagNaming map[string]NamingStrategy
+	// HeaderPrefix is prepended to derived header tag values, e.g. "X-".
+	HeaderPrefix string
+	// TagOptions lists options appended to every generated tag of a key, e.g.
+	// {"form": {"omitempty"}}.
+	TagOptions map[string][]string
+	// OptionalOmitEmpty adds omitempty to the binding location tags of fields
+	// declared optional.
+	OptionalOmitEmpty bool
+	// ValidationTags lists the tag keys (e.g. "binding" or "validate") that
+	// receive validator rules translated from buf.validate field constraints.
+	ValidationTags []string
+	// DeprecatedFields selects how deprecated fields are tagged. The zero
+	// value behaves like DeprecatedTag.
+	DeprecatedFields DeprecatedMode
+	// DefaultLocation is the file-wide location the request messages of the
+	// file's service methods start from, and DefaultAutoTags the auto tags
+	// every message starts from, before their own default_location and
+	// default_auto_tags options.
+	DefaultLocation binding.BindingLocation
+	DefaultAutoTags []string
+	// ServiceAutoTags replaces DefaultAutoTags for the request messages of the
+	// file's service methods.
+	ServiceAutoTags []string
+	// MethodLocations maps an upper-case HTTP method (e.g. "GET") to the
+	// default location of the request messages of the methods it routes.
+	MethodLocations map[string]binding.BindingLocation
+	// Rules are tagging rules from a rules file. They apply before the
+	// descriptor options of the message or field they match.
+	Rules *Rules
+	// CustomLocations defines locations beyond the built-in ones, selected
+	// with a "@name" entry in the manual tags of a field or rule.
+	CustomLocations []CustomLocation
+	// PruneTags removes the tags an earlier run added that the plugin owns but
+	// no longer generates, and restores json tags it no longer removes, when
+	// rewriting existing .pb.go files.
+	PruneTags bool
 }
 
 // DefaultConfig returns the configuration the plugin uses out of the box, i.e.
@@ -32,6 +133,7 @@ func DefaultConfig() *Config {
 	return &Config{
 		AutoRemoveJson: true,
 		BindingAliases: map[string][]string{},
+		PruneTags:      true,
 	}
 }
 
@@ -81,15 +183,85 @@ func ParseBindingAliases(aliasStr string) (map[string][]string, error) {
 	return aliases, nil
 }
 
+// ParseTagKeys parses and validates a comma-separated list of tag keys, as
+// used for auto tags and validation tags. Example: "binding,validate".
+func ParseTagKeys(keyStr string) ([]string, error) {
+	var keys []string
+	for _, key := range strings.Split(keyStr, ",") {
+		key = strings.TrimSpace(key)
+		if len(key) == 0 {
+			continue
+		}
+		if err := ValidateTagKey(key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// ParseTagOptions parses and validates tag options from a comma-separated
+// string of key=option entries. Repeated keys accumulate their options in
+// order. Example: "query=omitempty,form=omitempty".
+func ParseTagOptions(optionStr string) (map[string][]string, error) {
+	options := make(map[string][]string)
+	if optionStr == "" {
+		return options, nil
+	}
+
+	for _, entry := range strings.Split(optionStr, ",") {
+		entry = strings.TrimSpace(entry)
+		if len(entry) == 0 {
+			continue
+		}
+
+		key, option, found := strings.Cut(entry, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid tag option format '%s': expected 'key=option'", entry)
+		}
+
+		key = strings.TrimSpace(key)
+		option = strings.TrimSpace(option)
+
+		if err := ValidateTagKey(key); err != nil {
+			return nil, fmt.Errorf("invalid tag option '%s': %w", entry, err)
+		}
+		if len(option) == 0 || strings.ContainsAny(option, "`\"") {
+			return nil, fmt.Errorf("invalid tag option '%s': option must be non-empty and cannot contain quotes", entry)
+		}
+
+		options[key] = append(options[key], option)
+	}
+
+	return options, nil
+}
+
 // extractFile walks every top-level message in file and collects the struct
 // tags that should be applied to the generated Go structs. It is pure: it only
 // reads the descriptor and never touches the filesystem.
 func extractFile(file *protogen.File, config *Config) (StructTags, error) {
+	tags, _, err := extractFileBindings(file, config)
+	return tags, err
+}
+
+// extractFileBindings is extractFile also returning how the binding of every
+// field of file was resolved, in declaration order.
+func extractFileBindings(file *protogen.File, config *Config) (StructTags, []fieldBinding, error) {
+	inferred, err := inferFileLocations(file, config)
+	if err != nil {
+		return nil, nil, err
+	}
+	defaults, err := fileMessageDefaults(file, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	tags := make(StructTags)
+	var bindings []fieldBinding
 	for _, message := range file.Messages {
-		extraTags, err := extractMessage(message, binding.BindingLocation_BINDING_LOCATION_UNSPECIFIED, nil, config)
+		extraTags, err := extractMessage(message, defaults[message.Desc.FullName()], inferred, config, &bindings)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		for name, tag := range extraTags {
 			if len(tag) > 0 {
@@ -97,68 +269,114 @@ func extractFile(file *protogen.File, config *Config) (StructTags, error) {
 			}
 		}
 	}
-	return tags, nil
+	return tags, bindings, nil
 }
 
-func resolveLocationAndAutoTags(
-	options proto.Message,
-	locationExt protoreflect.ExtensionType,
-	autoTagsExt protoreflect.ExtensionType,
-	defaultLocation binding.BindingLocation,
-	defaultAutoTags []string,
-) (binding.BindingLocation, []string) {
-	location := defaultLocation
-	if proto.HasExtension(options, locationExt) {
-		location = proto.GetExtension(options, locationExt).(binding.BindingLocation)
-	}
-
-	autoTags := defaultAutoTags
-	if proto.HasExtension(options, autoTagsExt) {
-		autoTags = proto.GetExtension(options, autoTagsExt).([]string)
-	}
-
-	return location, autoTags
-}
-
-func setTag(tags *structtag.Tags, key, name string) error {
+func setTag(tags *structtag.Tags, key, name string, options []string) error {
 	if key == "" {
 		return nil
 	}
 	return tags.Set(&structtag.Tag{
 		Key:     key,
 		Name:    name,
-		Options: nil,
+		Options: options,
 	})
 }
 
-func setTagsByKeys(tags *structtag.Tags, keys []string, name string) error {
+func setTagsByKeys(tags *structtag.Tags, keys []string, name string, derived []string, config *Config) error {
 	for _, key := range keys {
-		if err := setTag(tags, key, name); err != nil {
+		if err := setTag(tags, key, name, tagOptions(key, derived, config)); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func extractMessage(message *protogen.Message, location binding.BindingLocation, autoTags []string, config *Config) (StructTags, error) {
+// tagOptions returns the options of the generated tag key: the options derived
+// from the field followed by those configured for key. An option named like an
+// earlier one (the part before '=') is dropped, so field metadata such as an
+// explicit default wins over the configured options.
+func tagOptions(key string, derived []string, config *Config) []string {
+	return uniqueOptions(slices.Concat(derived, config.TagOptions[key]))
+}
+
+// uniqueOptions drops every option named like an earlier one (the part before
+// '=').
+func uniqueOptions(options []string) []string {
+	var unique []string
+	seen := make(map[string]bool)
+	for _, option := range options {
+		name, _, _ := strings.Cut(option, "=")
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		unique = append(unique, option)
+	}
+	return unique
+}
+
+// fieldTagOptions derives the binding location tag options from the field
+// declaration: omitempty for optional fields when enabled, and the explicit
+// proto default value (proto2 and editions) as default=<value>.
+func fieldTagOptions(field *protogen.Field, config *Config) []string {
+	var options []string
+	if config.OptionalOmitEmpty && field.Desc.HasOptionalKeyword() {
+		options = append(options, "omitempty")
+	}
+	if value, ok := defaultValue(field.Desc); ok {
+		options = append(options, "default="+value)
+	}
+	return options
+}
+
+// defaultValue formats the explicit default of field the way a binder parses
+// it from a request string. Enums use their number, as the generated Go type
+// is an integer. Bytes defaults and strings that cannot be written inside a
+// struct tag option are skipped.
+func defaultValue(field protoreflect.FieldDescriptor) (string, bool) {
+	if !field.HasDefault() {
+		return "", false
+	}
+	switch field.Kind() {
+	case protoreflect.BytesKind:
+		return "", false
+	case protoreflect.EnumKind:
+		return strconv.Itoa(int(field.Default().Enum())), true
+	case protoreflect.StringKind:
+		value := field.Default().String()
+		if strings.ContainsAny(value, ",`\"") {
+			return "", false
+		}
+		return value, true
+	default:
+		return fmt.Sprint(field.Default().Interface()), true
+	}
+}
+
+// extractMessage collects the tags of message and its nested messages, starting
+// from scope, and appends the binding of each field to bindings.
+func extractMessage(message *protogen.Message, scope bindingScope, inferred fieldLocations, config *Config, bindings *[]fieldBinding) (StructTags, error) {
 	tags := make(StructTags)
 
-	location, autoTags = resolveLocationAndAutoTags(
-		message.Desc.Options(),
-		binding.E_DefaultLocation,
-		binding.E_DefaultAutoTags,
-		location,
-		autoTags,
-	)
+	name := message.Desc.FullName()
+	scope = scope.withRules(config.Rules.match(name), LevelMessageRule, name)
+	scope = scope.withOptions(message.Desc.Options(), binding.E_DefaultLocation, binding.E_DefaultAutoTags, LevelMessage, name)
 
 	messageTags := make(map[string]*structtag.Tags)
 
 	// process fields
 	for _, field := range message.Fields {
-		fieldTags, err := extractField(field, location, autoTags, config)
+		// Members of a real oneof live in their own wrapper struct and are
+		// handled below with the oneof level defaults.
+		if field.Oneof != nil && !field.Oneof.Desc.IsSynthetic() {
+			continue
+		}
+		fieldTags, fieldScope, err := extractField(field, inferred.apply(field, scope), config)
 		if err != nil {
 			return nil, err
 		}
+		*bindings = append(*bindings, fieldBinding{field: field, structName: message.GoIdent.GoName, scope: fieldScope, tags: fieldTags})
 		if fieldTags.Len() > 0 {
 			messageTags[field.GoName] = fieldTags
 		}
@@ -166,28 +384,52 @@ func extractMessage(message *protogen.Message, location binding.BindingLocation,
 
 	// process one_of
 	for _, oneOf := range message.Oneofs {
-		oneOfLocation, oneOfAutoTags := resolveLocationAndAutoTags(
-			oneOf.Desc.Options(),
-			binding.E_DefaultOneofLocation,
-			binding.E_DefaultOneofAutoTags,
-			location,
-			autoTags,
-		)
+		// Synthetic oneofs back proto3 optional fields, which protoc-gen-go
+		// keeps on the message struct itself.
+		if oneOf.Desc.IsSynthetic() {
+			continue
+		}
+		oneOfName := oneOf.Desc.FullName()
+		oneOfRules := config.Rules.match(oneOfName)
+		oneOfScope := scope.withRules(oneOfRules, LevelOneofRule, oneOfName)
+		oneOfScope = oneOfScope.withOptions(oneOf.Desc.Options(), binding.E_DefaultOneofLocation, binding.E_DefaultOneofAutoTags, LevelOneof, oneOfName)
+
+		// The oneof interface field itself only takes
+		// removals from rules.
+		if len(oneOfRules.removeTags) > 0 {
+			oneOfTags := &structtag.Tags{}
+			for _, key := range oneOfRules.removeTags {
+				if err := removeTag(oneOfTags, key); err != nil {
+					return nil, err
+				}
+			}
+			messageTags[oneOf.GoName] = oneOfTags
+		}
 
 		for _, field := range oneOf.Fields {
-			fieldTags, err := extractField(field, oneOfLocation, oneOfAutoTags, config)
+			fieldTags, fieldScope, err := extractField(field, inferred.apply(field, oneOfScope), config)
 			if err != nil {
 				return nil, err
 			}
+			*bindings = append(*bindings, fieldBinding{field: field, structName: field.GoIdent.GoName, scope: fieldScope, tags: fieldTags})
+			// protoc-gen-go emits each member in its own wrapper struct
+			// (e.g. Message_Field), so the tags are keyed by that struct.
 			if fieldTags.Len() > 0 {
-				messageTags[field.GoName] = fieldTags
+				tags[field.GoIdent.GoName] = map[string]*structtag.Tags{
+					field.GoName: fieldTags,
+				}
 			}
 		}
 	}
 
 	// process nested messages
 	for _, nested := range message.Messages {
-		extraTags, err := extractMessage(nested, location, autoTags, config)
+		// Map entries have no generated struct; their fields are Go map
+		// keys and values.
+		if nested.Desc.IsMapEntry() {
+			continue
+		}
+		extraTags, err := extractMessage(nested, scope, inferred, config, bindings)
 		if err != nil {
 			return nil, err
 		}
@@ -198,57 +440,239 @@ func extractMessage(message *protogen.Message, location binding.BindingLocation,
 	return tags, nil
 }
 
-func extractField(field *protogen.Field, location binding.BindingLocation, autoTags []string, config *Config) (*structtag.Tags, error) {
-	location, autoTags = resolveLocationAndAutoTags(
-		field.Desc.Options(),
-		binding.E_Location,
-		binding.E_AutoTags,
-		location,
-		autoTags,
-	)
+// extractField returns the tags of field, starting from scope, together with
+// the scope its own rules and options resolve to.
+func extractField(field *protogen.Field, scope bindingScope, config *Config) (*structtag.Tags, bindingScope, error) {
+	name := field.Desc.FullName()
+	rules := config.Rules.match(name)
+	scope = scope.withRules(rules, LevelFieldRule, name)
+	scope, err := locationDirective(rules.tags, scope, LevelFieldRule, name, config)
+	if err != nil {
+		return nil, scope, fmt.Errorf("%s: %w", name, err)
+	}
+	scope = scope.withOptions(field.Desc.Options(), binding.E_Location, binding.E_AutoTags, LevelField, name)
+	scope, err = locationDirective(stringsExtension(field.Desc.Options(), binding.E_Tags), scope, LevelField, name, config)
+	if err != nil {
+		return nil, scope, fmt.Errorf("%s: %w", name, err)
+	}
+
+	fieldTags, err := fieldScopeTags(field, scope, rules, config)
+	return fieldTags, scope, err
+}
+
+// fieldScopeTags generates the tags of field for its resolved scope.
+func fieldScopeTags(field *protogen.Field, scope bindingScope, rules ruleSettings, config *Config) (*structtag.Tags, error) {
+	location, autoTags := scope.location, scope.autoTags
+	if location == BindingLocationFile {
+		if err := checkFileField(field); err != nil {
+			return nil, err
+		}
+	}
 
 	fieldTags := &structtag.Tags{}
 	fieldName := string(field.Desc.Name())
 
+	options, _ := field.Desc.Options().(*descriptorpb.FieldOptions)
+	deprecated := options.GetDeprecated()
+	if deprecated && config.DeprecatedFields == DeprecatedSkip {
+		return manualTags(field, fieldTags, rules)
+	}
+
 	// Add auto tags
-	if err := setTagsByKeys(fieldTags, autoTags, fieldName); err != nil {
+	if err := setTagsByKeys(fieldTags, autoTags, fieldName, nil, config); err != nil {
 		return nil, err
 	}
 
 	// Add sphere binding tags
-	if tag, ok := noJsonBinding[location]; ok {
-		if err := setTag(fieldTags, tag, fieldName); err != nil {
+	if tag, ok := config.locationTag(location); ok {
+		bindingName := bindingTagName(field, tag, scope.naming, config)
+		options := fieldTagOptions(field, config)
+		if err := setTag(fieldTags, tag, bindingName, tagOptions(tag, options, config)); err != nil {
 			return nil, err
 		}
 		if aliases, exist := config.BindingAliases[tag]; exist {
-			if err := setTagsByKeys(fieldTags, aliases, fieldName); err != nil {
+			if err := setTagsByKeys(fieldTags, aliases, bindingName, options, config); err != nil {
+				return nil, err
+			}
+		}
+		if location == BindingLocationFile {
+			if err := setTag(fieldTags, fileMarkerKey, "true", nil); err != nil {
 				return nil, err
 			}
 		}
 		if config.AutoRemoveJson {
-			if err := setTag(fieldTags, "json", "-"); err != nil {
+			if err := setTag(fieldTags, "json", "-", nil); err != nil {
 				return nil, err
 			}
 		}
 	}
 
-	// Manual tags override all previous settings
+	// Add validation tags translated from buf.validate rules
+	if len(config.ValidationTags) > 0 {
+		if rule, _ := translateFieldRules(field.Desc); rule != "" {
+			for _, key := range config.ValidationTags {
+				if err := setTag(fieldTags, key, rule, nil); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if deprecated && config.DeprecatedFields == DeprecatedMark {
+		if err := setTag(fieldTags, "deprecated", "true", nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return manualTags(field, fieldTags, rules)
+}
+
+// checkFileField reports an error unless field can hold an uploaded file: the
+// file content as bytes, or a message the handler fills in from the
+// multipart.FileHeader. Repeated fields receive every file of the form key.
+func checkFileField(field *protogen.Field) error {
+	if field.Desc.IsMap() {
+		return fmt.Errorf("%s: file location requires a bytes or message field, got a map", field.Desc.FullName())
+	}
+	switch field.Desc.Kind() {
+	case protoreflect.BytesKind, protoreflect.MessageKind:
+		return nil
+	default:
+		return fmt.Errorf("%s: file location requires a bytes or message field, got %s", field.Desc.FullName(), field.Desc.Kind())
+	}
+}
+
+// locationDirectivePrefix starts a manual tags entry that selects the location
+// of the field by name instead of setting a tag, e.g. "@grpc_metadata".
+const locationDirectivePrefix = "@"
+
+// locationDirective sets the location of scope to the one named by the last
+// location directive in tags, if any, on behalf of the declaration name at
+// level. Directives name built-in locations as well as Config.CustomLocations.
+func locationDirective(tags []string, scope bindingScope, level string, name protoreflect.FullName, config *Config) (bindingScope, error) {
+	for _, tag := range tags {
+		location, ok := strings.CutPrefix(tag, locationDirectivePrefix)
+		if !ok {
+			continue
+		}
+		location = strings.TrimSpace(location)
+		if location == "" {
+			return scope, fmt.Errorf("invalid location directive '%s': missing location name", tag)
+		}
+		directive, err := config.location(location)
+		if err != nil {
+			return scope, err
+		}
+		scope = scope.setLocation(directive, level, string(name), strconv.Quote(tag))
+	}
+	return scope, nil
+}
+
+// manualTags applies the manual tags and tag removals of rules and then the
+// sphere.binding.tags of field on top of fieldTags. Manual tags override all
+// previous settings. A sphere.binding.tags entry starting with '-' lists keys
+// to remove instead, e.g. "-db -json".
+func manualTags(field *protogen.Field, fieldTags *structtag.Tags, rules ruleSettings) (*structtag.Tags, error) {
+	if err := applyManualTags(fieldTags, rules.tags); err != nil {
+		return nil, err
+	}
+	for _, key := range rules.removeTags {
+		if err := removeTag(fieldTags, key); err != nil {
+			return nil, err
+		}
+	}
 	if proto.HasExtension(field.Desc.Options(), binding.E_Tags) {
 		tags := proto.GetExtension(field.Desc.Options(), binding.E_Tags).([]string)
-		for _, tag := range tags {
-			if len(tag) == 0 {
-				continue
-			}
-			parse, err := structtag.Parse(tag)
+		if err := applyManualTags(fieldTags, tags); err != nil {
+			return nil, err
+		}
+	}
+	return fieldTags, nil
+}
+
+func applyManualTags(fieldTags *structtag.Tags, tags []string) error {
+	for _, tag := range tags {
+		if len(tag) == 0 || strings.HasPrefix(tag, locationDirectivePrefix) {
+			continue
+		}
+		if strings.HasPrefix(tag, removeTagPrefix) {
+			keys, err := parseRemoveDirective(tag)
 			if err != nil {
-				return nil, err
+				return err
 			}
-			for _, t := range parse.Tags() {
-				if err = fieldTags.Set(t); err != nil {
-					return nil, err
+			for _, key := range keys {
+				if err = removeTag(fieldTags, key); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		parse, err := structtag.Parse(tag)
+		if err != nil {
+			return err
+		}
+		for _, t := range parse.Tags() {
+			if t.Name == "" && len(t.Options) > 0 {
+				if err = mergeTagOptions(fieldTags, t); err != nil {
+					return err
 				}
+				continue
+			}
+			fieldTags.Delete(removeTagPrefix + t.Key)
+			if err = fieldTags.Set(t); err != nil {
+				return err
 			}
 		}
 	}
-	return fieldTags, nil
+	return nil
+}
+
+// mergeTagOptions adds the options of a manual tag without a name, such as
+// query:",default=1", to the tag of the same key set so far, keeping its name.
+// A manual option replaces an option of the same name.
+func mergeTagOptions(fieldTags *structtag.Tags, tag *structtag.Tag) error {
+	current, err := fieldTags.Get(tag.Key)
+	if err != nil {
+		return fmt.Errorf("invalid tag '%s': options without a name need a generated '%s' tag to add to", tag, tag.Key)
+	}
+	merged := *current
+	merged.Options = uniqueOptions(slices.Concat(tag.Options, current.Options))
+	return fieldTags.Set(&merged)
+}
+
+// parseRemoveDirective parses a manual tags entry such as "-db -json"
+// into the keys it removes.
+func parseRemoveDirective(directive string) ([]string, error) {
+	var keys []string
+	for _, word := range strings.Fields(directive) {
+		key, ok := strings.CutPrefix(word, removeTagPrefix)
+		if !ok {
+			return nil, fmt.Errorf("invalid tag removal '%s': every key must start with '%s'", directive, removeTagPrefix)
+		}
+		if err := validateRemovedTagKey(key); err != nil {
+			return nil, fmt.Errorf("invalid tag removal '%s': %w", directive, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// validateRemovedTagKey validates a tag key to remove. The protoc-gen-go keys
+// other than json are read by the protobuf runtime, which panics without
+// them, so they cannot be removed.
+func validateRemovedTagKey(key string) error {
+	if err := ValidateTagKey(key); err != nil {
+		return err
+	}
+	if protocGenGoTagKeys[key] && key != "json" {
+		return fmt.Errorf("tag key '%s' is read by the protobuf runtime and cannot be removed", key)
+	}
+	return nil
+}
+
+// removeTag records that key must be deleted from the generated field,
+// dropping any tag the plugin generated for key itself.
+func removeTag(tags *structtag.Tags, key string) error {
+	tags.Delete(key)
+	return tags.Set(&structtag.Tag{Key: removeTagPrefix + key})
 }
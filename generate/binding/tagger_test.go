@@ -6,6 +6,7 @@ import (
 
 	"github.com/fatih/structtag"
 	"github.com/go-sphere/binding/sphere/binding"
+	"github.com/go-sphere/protoc-gen-sphere-binding/generate/internal/testutil"
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/descriptorpb"
@@ -89,16 +90,25 @@ func TestParseBindingAliases(t *testing.T) {
 func TestSetTag(t *testing.T) {
 	t.Run("sets a key", func(t *testing.T) {
 		tags := &structtag.Tags{}
-		if err := setTag(tags, "query", "name"); err != nil {
+		if err := setTag(tags, "query", "name", nil); err != nil {
 			t.Fatal(err)
 		}
 		if got, want := tags.String(), `query:"name"`; got != want {
 			t.Fatalf("setTag = %q, want %q", got, want)
 		}
 	})
+	t.Run("sets options", func(t *testing.T) {
+		tags := &structtag.Tags{}
+		if err := setTag(tags, "query", "name", []string{"omitempty", "default=1"}); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := tags.String(), `query:"name,omitempty,default=1"`; got != want {
+			t.Fatalf("setTag = %q, want %q", got, want)
+		}
+	})
 	t.Run("empty key is a no-op", func(t *testing.T) {
 		tags := &structtag.Tags{}
-		if err := setTag(tags, "", "name"); err != nil {
+		if err := setTag(tags, "", "name", nil); err != nil {
 			t.Fatal(err)
 		}
 		if tags.Len() != 0 {
@@ -109,28 +119,77 @@ func TestSetTag(t *testing.T) {
 
 func TestSetTagsByKeys(t *testing.T) {
 	tags := &structtag.Tags{}
-	if err := setTagsByKeys(tags, []string{"query", "form"}, "name"); err != nil {
+	config := &Config{TagOptions: map[string][]string{"form": {"omitempty"}}}
+	if err := setTagsByKeys(tags, []string{"query", "form"}, "name", nil, config); err != nil {
 		t.Fatal(err)
 	}
-	if got, want := tags.String(), `query:"name" form:"name"`; got != want {
+	if got, want := tags.String(), `query:"name" form:"name,omitempty"`; got != want {
 		t.Fatalf("setTagsByKeys = %q, want %q", got, want)
 	}
 }
 
-func TestResolveLocationAndAutoTags(t *testing.T) {
-	const defaultLoc = binding.BindingLocation_BINDING_LOCATION_QUERY
+func TestParseTagOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    map[string][]string
+		wantErr bool
+	}{
+		{"empty", "", map[string][]string{}, false},
+		{
+			name:  "accumulates_per_key",
+			input: "query=omitempty, form=default=1,query=default=10",
+			want: map[string][]string{
+				"query": {"omitempty", "default=10"},
+				"form":  {"default=1"},
+			},
+		},
+		{"missing_option", "query", nil, true},
+		{"empty_option", "query=", nil, true},
+		{"quoted_option", `query=default="1"`, nil, true},
+		{"invalid_key", "qu ery=omitempty", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTagOptions(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTagOptions(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ParseTagOptions(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagOptions(t *testing.T) {
+	config := &Config{TagOptions: map[string][]string{"query": {"omitempty", "default=5"}}}
+	got := tagOptions("query", []string{"default=1"}, config)
+	if want := []string{"default=1", "omitempty"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("tagOptions = %v, want %v", got, want)
+	}
+	if got := tagOptions("uri", nil, config); got != nil {
+		t.Fatalf("tagOptions(uri) = %v, want nil", got)
+	}
+}
+
+func TestBindingScopeWithOptions(t *testing.T) {
+	start := defaultScope().setLocation(binding.BindingLocation_BINDING_LOCATION_QUERY, LevelFile, "a.proto", "default_location")
+	start = start.setAutoTags([]string{"validate"}, LevelFile, "a.proto", "default_auto_tags")
 
 	t.Run("falls back to defaults when unset", func(t *testing.T) {
-		opts := &descriptorpb.MessageOptions{}
-		loc, autoTags := resolveLocationAndAutoTags(
-			opts, binding.E_DefaultLocation, binding.E_DefaultAutoTags,
-			defaultLoc, []string{"validate"},
-		)
-		if loc != defaultLoc {
-			t.Fatalf("location = %v, want %v", loc, defaultLoc)
+		scope := start.withOptions(&descriptorpb.MessageOptions{}, binding.E_DefaultLocation, binding.E_DefaultAutoTags, LevelMessage, "a.M")
+		if scope.location != start.location || !reflect.DeepEqual(scope.autoTags, start.autoTags) {
+			t.Fatalf("scope = %+v, want %+v", scope, start)
+		}
+		if scope.locationSource != start.locationSource || scope.autoTagsSource != start.autoTagsSource {
+			t.Fatalf("sources = %v, %v, want the file defaults", scope.locationSource, scope.autoTagsSource)
 		}
-		if !reflect.DeepEqual(autoTags, []string{"validate"}) {
-			t.Fatalf("autoTags = %v, want [validate]", autoTags)
+		if last := scope.trace[len(scope.trace)-1]; last.setsLocation || last.setsAutoTags || last.source.Level != LevelMessage {
+			t.Fatalf("last step = %+v, want a message step that sets nothing", last)
 		}
 	})
 
@@ -139,15 +198,16 @@ func TestResolveLocationAndAutoTags(t *testing.T) {
 		proto.SetExtension(opts, binding.E_DefaultLocation, binding.BindingLocation_BINDING_LOCATION_URI)
 		proto.SetExtension(opts, binding.E_DefaultAutoTags, []string{"form", "db"})
 
-		loc, autoTags := resolveLocationAndAutoTags(
-			opts, binding.E_DefaultLocation, binding.E_DefaultAutoTags,
-			defaultLoc, nil,
-		)
-		if loc != binding.BindingLocation_BINDING_LOCATION_URI {
-			t.Fatalf("location = %v, want URI", loc)
+		scope := start.withOptions(opts, binding.E_DefaultLocation, binding.E_DefaultAutoTags, LevelMessage, "a.M")
+		if scope.location != binding.BindingLocation_BINDING_LOCATION_URI {
+			t.Fatalf("location = %v, want URI", scope.location)
+		}
+		if !reflect.DeepEqual(scope.autoTags, []string{"form", "db"}) {
+			t.Fatalf("autoTags = %v, want [form db]", scope.autoTags)
 		}
-		if !reflect.DeepEqual(autoTags, []string{"form", "db"}) {
-			t.Fatalf("autoTags = %v, want [form db]", autoTags)
+		want := BindingSource{Level: LevelMessage, Name: "a.M"}
+		if scope.locationSource != want || scope.autoTagsSource != want {
+			t.Fatalf("sources = %v, %v, want %v", scope.locationSource, scope.autoTagsSource, want)
 		}
 	})
 }
@@ -206,4 +266,170 @@ func TestDefaultConfig(t *testing.T) {
 	if cfg.BindingAliases == nil {
 		t.Error("DefaultConfig().BindingAliases = nil, want non-nil")
 	}
+	if !cfg.PruneTags {
+		t.Error("DefaultConfig().PruneTags = false, want true")
+	}
+}
+
+// TestExtractFile_OneofWrapperStructs verifies that oneof members are keyed by
+// the wrapper struct protoc-gen-go emits for them (OneofRequest_ByName) rather
+// than by the parent message struct, where no such field exists.
+func TestExtractFile_OneofWrapperStructs(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/oneof.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "oneof.proto")
+	file := testutil.FileToGenerate(t, plugin)
+
+	tags, err := extractFile(file, DefaultConfig())
+	if err != nil {
+		t.Fatalf("extractFile failed: %v", err)
+	}
+	if _, ok := tags["OneofRequest"]["ByName"]; ok {
+		t.Error("oneof member ByName must not be keyed by the parent struct")
+	}
+	byName, ok := tags["OneofRequest_ByName"]["ByName"]
+	if !ok {
+		t.Fatalf("missing tags for OneofRequest_ByName.ByName, got %v", tags)
+	}
+	if got, want := byName.String(), `validate:"by_name" uri:"by_name" json:"-"`; got != want {
+		t.Fatalf("OneofRequest_ByName.ByName tags = %q, want %q", got, want)
+	}
+}
+
+func TestParseDeprecatedMode(t *testing.T) {
+	tests := map[string]DeprecatedMode{
+		"":     DeprecatedTag,
+		"tag":  DeprecatedTag,
+		"skip": DeprecatedSkip,
+		"mark": DeprecatedMark,
+	}
+	for input, want := range tests {
+		got, err := ParseDeprecatedMode(input)
+		if err != nil {
+			t.Fatalf("ParseDeprecatedMode(%q) unexpected error: %v", input, err)
+		}
+		if got != want {
+			t.Fatalf("ParseDeprecatedMode(%q) = %q, want %q", input, got, want)
+		}
+	}
+	if _, err := ParseDeprecatedMode("drop"); err == nil {
+		t.Fatal("expected error for unknown mode")
+	}
+}
+
+func TestParseTagKeys(t *testing.T) {
+	got, err := ParseTagKeys("binding, validate,")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"binding", "validate"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("ParseTagKeys = %q, want %q", got, want)
+	}
+	if _, err := ParseTagKeys("bin ding"); err == nil {
+		t.Fatal("expected error for invalid tag key")
+	}
+}
+
+func TestParseRemoveDirective(t *testing.T) {
+	got, err := parseRemoveDirective("-db  -json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"db", "json"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseRemoveDirective = %q, want %q", got, want)
+	}
+	for _, directive := range []string{"-db json", "-", "-a:b", "-protobuf", "-db -protobuf_oneof", "-protobuf_key", "-protobuf_val"} {
+		if _, err := parseRemoveDirective(directive); err == nil {
+			t.Errorf("parseRemoveDirective(%q) expected error", directive)
+		}
+	}
+}
+
+func TestApplyManualTags_LastDirectiveWins(t *testing.T) {
+	tags := &structtag.Tags{}
+	if err := applyManualTags(tags, []string{`db:"a"`, "-db", `query:"q"`, "-query", `query:"r"`}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := tags.String(), `-db:"" query:"r"`; got != want {
+		t.Fatalf("tags = %q, want %q", got, want)
+	}
+}
+
+func TestApplyManualTags_MergeOptions(t *testing.T) {
+	tags, err := structtag.Parse(`json:"-" query:"page,omitempty,default=2"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := applyManualTags(tags, []string{`query:",default=1,required"`}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := tags.String(), `json:"-" query:"page,default=1,required,omitempty"`; got != want {
+		t.Fatalf("tags = %q, want %q", got, want)
+	}
+	if err := applyManualTags(tags, []string{`form:",default=1"`}); err == nil {
+		t.Fatal("expected an error for options without a generated tag")
+	}
+}
+
+func TestExtractFile_FileLocation(t *testing.T) {
+	rule := encodeHTTPRule(httpRulePost, "/v1/books", "*")
+
+	cfg := DefaultConfig()
+	cfg.Rules = mustParseRules(t, `
+rules:
+  - match: api.v1.GetBookRequest.book
+    location: file
+`)
+	tags, err := extractFile(httpTestFile(t, rule), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := tags["GetBookRequest"]["Book"].String(), `form:"book" file:"true" json:"-"`; got != want {
+		t.Errorf("GetBookRequest.Book tags = %q, want %q", got, want)
+	}
+
+	cfg.Rules = mustParseRules(t, `
+rules:
+  - match: api.v1.GetBookRequest.name
+    location: file
+`)
+	_, err = extractFile(httpTestFile(t, rule), cfg)
+	if err == nil || err.Error() != "api.v1.GetBookRequest.name: file location requires a bytes or message field, got string" {
+		t.Fatalf("expected a field type error, got %v", err)
+	}
+}
+
+func TestExtractFile_LocationDirective(t *testing.T) {
+	rule := encodeHTTPRule(httpRulePost, "/v1/books", "*")
+	rules := mustParseRules(t, `
+rules:
+  - match: api.v1.GetBookRequest.name
+    tags: ['@grpc_metadata']
+  - match: api.v1.GetBookRequest.shelf
+    tags: ['@grpc_metadata']
+`)
+
+	cfg := DefaultConfig()
+	cfg.Rules = rules
+	cfg.CustomLocations = []CustomLocation{{Name: "grpc_metadata", TagKey: "metadata"}}
+	tags, err := extractFile(httpTestFile(t, rule), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		// The rule directive selects the custom location...
+		"Name": `metadata:"name" json:"-"`,
+		// ...but loses against the location option of the field.
+		"Shelf": `header:"Shelf" json:"-"`,
+	}
+	for field, value := range want {
+		if got := tags["GetBookRequest"][field]; got == nil || got.String() != value {
+			t.Errorf("GetBookRequest.%s tags = %v, want %q", field, got, value)
+		}
+	}
+
+	cfg.CustomLocations = nil
+	_, err = extractFile(httpTestFile(t, rule), cfg)
+	if err == nil || err.Error() != "api.v1.GetBookRequest.name: unknown binding location 'grpc_metadata'" {
+		t.Fatalf("expected an undefined location error, got %v", err)
+	}
 }
@@ -28,7 +28,7 @@ const (
 type BasicRequest struct {
 	state       protoimpl.MessageState `protogen:"open.v1"`
 	PathId      string                 `protobuf:"bytes,1,opt,name=path_id,json=pathId,proto3" json:"-" uri:"path_id"`
-	HeaderToken string                 `protobuf:"bytes,2,opt,name=header_token,json=headerToken,proto3" json:"-" header:"header_token"`
+	HeaderToken string                 `protobuf:"bytes,2,opt,name=header_token,json=headerToken,proto3" json:"-" header:"Header-Token"`
 	FormName    string                 `protobuf:"bytes,3,opt,name=form_name,json=formName,proto3" json:"-" form:"form_name"`
 	// Falls back to the message default (QUERY).
 	Keyword string `protobuf:"bytes,4,opt,name=keyword,proto3" json:"-" query:"keyword"`
@@ -28,7 +28,7 @@ const (
 type BasicRequest struct {
 	state       protoimpl.MessageState `protogen:"open.v1"`
 	PathId      string                 `protobuf:"bytes,1,opt,name=path_id,json=pathId,proto3" json:"path_id,omitempty" path:"path_id" uri:"path_id"`
-	HeaderToken string                 `protobuf:"bytes,2,opt,name=header_token,json=headerToken,proto3" json:"header_token,omitempty" header:"header_token"`
+	HeaderToken string                 `protobuf:"bytes,2,opt,name=header_token,json=headerToken,proto3" json:"header_token,omitempty" header:"Header-Token"`
 	FormName    string                 `protobuf:"bytes,3,opt,name=form_name,json=formName,proto3" json:"form_name,omitempty" form:"form_name"`
 	// Falls back to the message default (QUERY).
 	Keyword string `protobuf:"bytes,4,opt,name=keyword,proto3" json:"keyword,omitempty" form:"keyword" query:"keyword"`
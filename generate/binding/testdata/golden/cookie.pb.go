@@ -0,0 +1,156 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: cookie.proto
+
+package cookiev1
+
+import (
+	_ "github.com/go-sphere/binding/sphere/binding"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SessionRequest exercises the cookie location. Proto options cannot name it,
+// so testdata/rules/cookie.yaml moves the session fields there.
+type SessionRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SessionId string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"-" cookie:"session_id"`
+	CsrfToken string                 `protobuf:"bytes,2,opt,name=csrf_token,json=csrfToken,proto3" json:"-" cookie:"csrf_token"`
+	// Stays a query parameter.
+	Redirect string `protobuf:"bytes,3,opt,name=redirect,proto3" json:"-" query:"redirect"`
+	// Manual tags still win over the cookie tag.
+	Theme         string `protobuf:"bytes,4,opt,name=theme,proto3" json:"-" cookie:"ui_theme"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionRequest) Reset() {
+	*x = SessionRequest{}
+	mi := &file_cookie_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionRequest) ProtoMessage() {}
+
+func (x *SessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cookie_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionRequest.ProtoReflect.Descriptor instead.
+func (*SessionRequest) Descriptor() ([]byte, []int) {
+	return file_cookie_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *SessionRequest) GetCsrfToken() string {
+	if x != nil {
+		return x.CsrfToken
+	}
+	return ""
+}
+
+func (x *SessionRequest) GetRedirect() string {
+	if x != nil {
+		return x.Redirect
+	}
+	return ""
+}
+
+func (x *SessionRequest) GetTheme() string {
+	if x != nil {
+		return x.Theme
+	}
+	return ""
+}
+
+var File_cookie_proto protoreflect.FileDescriptor
+
+const file_cookie_proto_rawDesc = "" +
+	"\n" +
+	"\fcookie.proto\x12\x12testdata.cookie.v1\x1a\x1csphere/binding/binding.proto\"\xa1\x01\n" +
+	"\x0eSessionRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x1d\n" +
+	"\n" +
+	"csrf_token\x18\x02 \x01(\tR\tcsrfToken\x12\x1a\n" +
+	"\bredirect\x18\x03 \x01(\tR\bredirect\x12-\n" +
+	"\x05theme\x18\x04 \x01(\tB\x17ʝ\xa6\x89\x04\x11cookie:\"ui_theme\"R\x05theme:\x06\xa0\x9c\xa6\x89\x04\x01B`Z^github.com/go-sphere/protoc-gen-sphere-binding/generate/binding/testdata/gen/cookiev1;cookiev1b\x06proto3"
+
+var (
+	file_cookie_proto_rawDescOnce sync.Once
+	file_cookie_proto_rawDescData []byte
+)
+
+func file_cookie_proto_rawDescGZIP() []byte {
+	file_cookie_proto_rawDescOnce.Do(func() {
+		file_cookie_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_cookie_proto_rawDesc), len(file_cookie_proto_rawDesc)))
+	})
+	return file_cookie_proto_rawDescData
+}
+
+var file_cookie_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_cookie_proto_goTypes = []any{
+	(*SessionRequest)(nil), // 0: testdata.cookie.v1.SessionRequest
+}
+var file_cookie_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_cookie_proto_init() }
+func file_cookie_proto_init() {
+	if File_cookie_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cookie_proto_rawDesc), len(file_cookie_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_cookie_proto_goTypes,
+		DependencyIndexes: file_cookie_proto_depIdxs,
+		MessageInfos:      file_cookie_proto_msgTypes,
+	}.Build()
+	File_cookie_proto = out.File
+	file_cookie_proto_goTypes = nil
+	file_cookie_proto_depIdxs = nil
+}
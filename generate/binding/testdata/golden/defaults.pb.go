@@ -0,0 +1,267 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: defaults.proto
+
+package defaultsv1
+
+import (
+	_ "github.com/go-sphere/binding/sphere/binding"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Order int32
+
+const (
+	Order_ORDER_ASC  Order = 0
+	Order_ORDER_DESC Order = 1
+)
+
+// Enum value maps for Order.
+var (
+	Order_name = map[int32]string{
+		0: "ORDER_ASC",
+		1: "ORDER_DESC",
+	}
+	Order_value = map[string]int32{
+		"ORDER_ASC":  0,
+		"ORDER_DESC": 1,
+	}
+)
+
+func (x Order) Enum() *Order {
+	p := new(Order)
+	*p = x
+	return p
+}
+
+func (x Order) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Order) Descriptor() protoreflect.EnumDescriptor {
+	return file_defaults_proto_enumTypes[0].Descriptor()
+}
+
+func (Order) Type() protoreflect.EnumType {
+	return &file_defaults_proto_enumTypes[0]
+}
+
+func (x Order) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *Order) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = Order(num)
+	return nil
+}
+
+// Deprecated: Use Order.Descriptor instead.
+func (Order) EnumDescriptor() ([]byte, []int) {
+	return file_defaults_proto_rawDescGZIP(), []int{0}
+}
+
+// DefaultsRequest exercises explicit proto2 default values, which become the
+// default= option of the binding tags.
+type DefaultsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Page           *int32                 `protobuf:"varint,1,opt,name=page,def=1" json:"-" query:"page,default=1"`
+	PageSize       *int32                 `protobuf:"varint,2,opt,name=page_size,json=pageSize,def=20" json:"-" query:"page_size,default=20"`
+	IncludeDeleted *bool                  `protobuf:"varint,3,opt,name=include_deleted,json=includeDeleted,def=0" json:"-" query:"include_deleted,default=false"`
+	Order          *Order                 `protobuf:"varint,4,opt,name=order,enum=testdata.defaults.v1.Order,def=1" json:"-" query:"order,default=1"`
+	Category       *string                `protobuf:"bytes,5,opt,name=category,def=all" json:"-" query:"category,default=all"`
+	// Commas cannot be encoded in a struct tag option, so no default is emitted.
+	Fields   *string  `protobuf:"bytes,6,opt,name=fields,def=id,name" json:"-" query:"fields"`
+	MinScore *float64 `protobuf:"fixed64,7,opt,name=min_score,json=minScore,def=0.5" json:"-" query:"min_score,default=0.5"`
+	// JSON fields have no binding tag to carry the default.
+	Note          *string `protobuf:"bytes,8,opt,name=note,def=none" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+// Default values for DefaultsRequest fields.
+const (
+	Default_DefaultsRequest_Page           = int32(1)
+	Default_DefaultsRequest_PageSize       = int32(20)
+	Default_DefaultsRequest_IncludeDeleted = bool(false)
+	Default_DefaultsRequest_Order          = Order_ORDER_DESC
+	Default_DefaultsRequest_Category       = string("all")
+	Default_DefaultsRequest_Fields         = string("id,name")
+	Default_DefaultsRequest_MinScore       = float64(0.5)
+	Default_DefaultsRequest_Note           = string("none")
+)
+
+func (x *DefaultsRequest) Reset() {
+	*x = DefaultsRequest{}
+	mi := &file_defaults_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DefaultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DefaultsRequest) ProtoMessage() {}
+
+func (x *DefaultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_defaults_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DefaultsRequest.ProtoReflect.Descriptor instead.
+func (*DefaultsRequest) Descriptor() ([]byte, []int) {
+	return file_defaults_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DefaultsRequest) GetPage() int32 {
+	if x != nil && x.Page != nil {
+		return *x.Page
+	}
+	return Default_DefaultsRequest_Page
+}
+
+func (x *DefaultsRequest) GetPageSize() int32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return Default_DefaultsRequest_PageSize
+}
+
+func (x *DefaultsRequest) GetIncludeDeleted() bool {
+	if x != nil && x.IncludeDeleted != nil {
+		return *x.IncludeDeleted
+	}
+	return Default_DefaultsRequest_IncludeDeleted
+}
+
+func (x *DefaultsRequest) GetOrder() Order {
+	if x != nil && x.Order != nil {
+		return *x.Order
+	}
+	return Default_DefaultsRequest_Order
+}
+
+func (x *DefaultsRequest) GetCategory() string {
+	if x != nil && x.Category != nil {
+		return *x.Category
+	}
+	return Default_DefaultsRequest_Category
+}
+
+func (x *DefaultsRequest) GetFields() string {
+	if x != nil && x.Fields != nil {
+		return *x.Fields
+	}
+	return Default_DefaultsRequest_Fields
+}
+
+func (x *DefaultsRequest) GetMinScore() float64 {
+	if x != nil && x.MinScore != nil {
+		return *x.MinScore
+	}
+	return Default_DefaultsRequest_MinScore
+}
+
+func (x *DefaultsRequest) GetNote() string {
+	if x != nil && x.Note != nil {
+		return *x.Note
+	}
+	return Default_DefaultsRequest_Note
+}
+
+var File_defaults_proto protoreflect.FileDescriptor
+
+const file_defaults_proto_rawDesc = "" +
+	"\n" +
+	"\x0edefaults.proto\x12\x14testdata.defaults.v1\x1a\x1csphere/binding/binding.proto\"\xc6\x02\n" +
+	"\x0fDefaultsRequest\x12\x15\n" +
+	"\x04page\x18\x01 \x01(\x05:\x011R\x04page\x12\x1f\n" +
+	"\tpage_size\x18\x02 \x01(\x05:\x0220R\bpageSize\x12.\n" +
+	"\x0finclude_deleted\x18\x03 \x01(\b:\x05falseR\x0eincludeDeleted\x12=\n" +
+	"\x05order\x18\x04 \x01(\x0e2\x1b.testdata.defaults.v1.Order:\n" +
+	"ORDER_DESCR\x05order\x12\x1f\n" +
+	"\bcategory\x18\x05 \x01(\t:\x03allR\bcategory\x12\x1f\n" +
+	"\x06fields\x18\x06 \x01(\t:\aid,nameR\x06fields\x12 \n" +
+	"\tmin_score\x18\a \x01(\x01:\x030.5R\bminScore\x12 \n" +
+	"\x04note\x18\b \x01(\t:\x04noneB\x06\xc0\x9d\xa6\x89\x04\x03R\x04note:\x06\xa0\x9c\xa6\x89\x04\x01*&\n" +
+	"\x05Order\x12\r\n" +
+	"\tORDER_ASC\x10\x00\x12\x0e\n" +
+	"\n" +
+	"ORDER_DESC\x10\x01BdZbgithub.com/go-sphere/protoc-gen-sphere-binding/generate/binding/testdata/gen/defaultsv1;defaultsv1"
+
+var (
+	file_defaults_proto_rawDescOnce sync.Once
+	file_defaults_proto_rawDescData []byte
+)
+
+func file_defaults_proto_rawDescGZIP() []byte {
+	file_defaults_proto_rawDescOnce.Do(func() {
+		file_defaults_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_defaults_proto_rawDesc), len(file_defaults_proto_rawDesc)))
+	})
+	return file_defaults_proto_rawDescData
+}
+
+var file_defaults_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_defaults_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_defaults_proto_goTypes = []any{
+	(Order)(0),              // 0: testdata.defaults.v1.Order
+	(*DefaultsRequest)(nil), // 1: testdata.defaults.v1.DefaultsRequest
+}
+var file_defaults_proto_depIdxs = []int32{
+	0, // 0: testdata.defaults.v1.DefaultsRequest.order:type_name -> testdata.defaults.v1.Order
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_defaults_proto_init() }
+func file_defaults_proto_init() {
+	if File_defaults_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_defaults_proto_rawDesc), len(file_defaults_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_defaults_proto_goTypes,
+		DependencyIndexes: file_defaults_proto_depIdxs,
+		EnumInfos:         file_defaults_proto_enumTypes,
+		MessageInfos:      file_defaults_proto_msgTypes,
+	}.Build()
+	File_defaults_proto = out.File
+	file_defaults_proto_goTypes = nil
+	file_defaults_proto_depIdxs = nil
+}
@@ -0,0 +1,163 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: field_options.proto
+
+package field_optionsv1
+
+import (
+	_ "github.com/go-sphere/binding/sphere/binding"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// FieldOptionsRequest exercises the standard json_name and deprecated field
+// options.
+type FieldOptionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A custom json_name is used by the json naming strategy.
+	PageToken string `protobuf:"bytes,1,opt,name=page_token,json=cursor,proto3" json:"-" query:"page_token"`
+	PageSize  int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"-" query:"page_size"`
+	// Deprecated fields are tagged, skipped or marked depending on
+	// deprecated_fields.
+	//
+	// Deprecated: Marked as deprecated in field_options.proto.
+	Order string `protobuf:"bytes,3,opt,name=order,proto3" json:"-" query:"order"`
+	// Manual tags of deprecated fields always apply.
+	//
+	// Deprecated: Marked as deprecated in field_options.proto.
+	Filter        string `protobuf:"bytes,4,opt,name=filter,proto3" json:"-" query:"q"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldOptionsRequest) Reset() {
+	*x = FieldOptionsRequest{}
+	mi := &file_field_options_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldOptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldOptionsRequest) ProtoMessage() {}
+
+func (x *FieldOptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_field_options_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldOptionsRequest.ProtoReflect.Descriptor instead.
+func (*FieldOptionsRequest) Descriptor() ([]byte, []int) {
+	return file_field_options_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FieldOptionsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *FieldOptionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in field_options.proto.
+func (x *FieldOptionsRequest) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in field_options.proto.
+func (x *FieldOptionsRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+var File_field_options_proto protoreflect.FileDescriptor
+
+const file_field_options_proto_rawDesc = "" +
+	"\n" +
+	"\x13field_options.proto\x12\x19testdata.field_options.v1\x1a\x1csphere/binding/binding.proto\"\x9b\x01\n" +
+	"\x13FieldOptionsRequest\x12\x1a\n" +
+	"\n" +
+	"page_token\x18\x01 \x01(\tR\x06cursor\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x18\n" +
+	"\x05order\x18\x03 \x01(\tB\x02\x18\x01R\x05order\x12)\n" +
+	"\x06filter\x18\x04 \x01(\tB\x11ʝ\xa6\x89\x04\tquery:\"q\"\x18\x01R\x06filter:\x06\xa0\x9c\xa6\x89\x04\x01BnZlgithub.com/go-sphere/protoc-gen-sphere-binding/generate/binding/testdata/gen/field_optionsv1;field_optionsv1b\x06proto3"
+
+var (
+	file_field_options_proto_rawDescOnce sync.Once
+	file_field_options_proto_rawDescData []byte
+)
+
+func file_field_options_proto_rawDescGZIP() []byte {
+	file_field_options_proto_rawDescOnce.Do(func() {
+		file_field_options_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_field_options_proto_rawDesc), len(file_field_options_proto_rawDesc)))
+	})
+	return file_field_options_proto_rawDescData
+}
+
+var file_field_options_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_field_options_proto_goTypes = []any{
+	(*FieldOptionsRequest)(nil), // 0: testdata.field_options.v1.FieldOptionsRequest
+}
+var file_field_options_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_field_options_proto_init() }
+func file_field_options_proto_init() {
+	if File_field_options_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_field_options_proto_rawDesc), len(file_field_options_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_field_options_proto_goTypes,
+		DependencyIndexes: file_field_options_proto_depIdxs,
+		MessageInfos:      file_field_options_proto_msgTypes,
+	}.Build()
+	File_field_options_proto = out.File
+	file_field_options_proto_goTypes = nil
+	file_field_options_proto_depIdxs = nil
+}
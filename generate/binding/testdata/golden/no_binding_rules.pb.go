@@ -0,0 +1,180 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: no_binding.proto
+
+package nobindingv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// NoBindingRequest has no sphere.binding options, so the plugin must leave the
+// generated struct tags untouched (no file is rewritten).
+type NoBindingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"-" uri:"name"`
+	Age           int64                  `protobuf:"varint,2,opt,name=age,proto3" json:"-" query:"age" validate:"gte=0"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NoBindingRequest) Reset() {
+	*x = NoBindingRequest{}
+	mi := &file_no_binding_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NoBindingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoBindingRequest) ProtoMessage() {}
+
+func (x *NoBindingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_no_binding_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoBindingRequest.ProtoReflect.Descriptor instead.
+func (*NoBindingRequest) Descriptor() ([]byte, []int) {
+	return file_no_binding_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *NoBindingRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NoBindingRequest) GetAge() int64 {
+	if x != nil {
+		return x.Age
+	}
+	return 0
+}
+
+type NoBindingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            string                 `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NoBindingResponse) Reset() {
+	*x = NoBindingResponse{}
+	mi := &file_no_binding_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NoBindingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoBindingResponse) ProtoMessage() {}
+
+func (x *NoBindingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_no_binding_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoBindingResponse.ProtoReflect.Descriptor instead.
+func (*NoBindingResponse) Descriptor() ([]byte, []int) {
+	return file_no_binding_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *NoBindingResponse) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+var File_no_binding_proto protoreflect.FileDescriptor
+
+const file_no_binding_proto_rawDesc = "" +
+	"\n" +
+	"\x10no_binding.proto\x12\x15testdata.nobinding.v1\"8\n" +
+	"\x10NoBindingRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03age\x18\x02 \x01(\x03R\x03age\"#\n" +
+	"\x11NoBindingResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\tR\x02okBfZdgithub.com/go-sphere/protoc-gen-sphere-binding/generate/binding/testdata/gen/nobindingv1;nobindingv1b\x06proto3"
+
+var (
+	file_no_binding_proto_rawDescOnce sync.Once
+	file_no_binding_proto_rawDescData []byte
+)
+
+func file_no_binding_proto_rawDescGZIP() []byte {
+	file_no_binding_proto_rawDescOnce.Do(func() {
+		file_no_binding_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_no_binding_proto_rawDesc), len(file_no_binding_proto_rawDesc)))
+	})
+	return file_no_binding_proto_rawDescData
+}
+
+var file_no_binding_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_no_binding_proto_goTypes = []any{
+	(*NoBindingRequest)(nil),  // 0: testdata.nobinding.v1.NoBindingRequest
+	(*NoBindingResponse)(nil), // 1: testdata.nobinding.v1.NoBindingResponse
+}
+var file_no_binding_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_no_binding_proto_init() }
+func file_no_binding_proto_init() {
+	if File_no_binding_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_no_binding_proto_rawDesc), len(file_no_binding_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_no_binding_proto_goTypes,
+		DependencyIndexes: file_no_binding_proto_depIdxs,
+		MessageInfos:      file_no_binding_proto_msgTypes,
+	}.Build()
+	File_no_binding_proto = out.File
+	file_no_binding_proto_goTypes = nil
+	file_no_binding_proto_depIdxs = nil
+}
@@ -0,0 +1,179 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: options.proto
+
+package optionsv1
+
+import (
+	_ "github.com/go-sphere/binding/sphere/binding"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// OptionsRequest exercises tag options: proto3 optional fields may get
+// omitempty on their binding tags, and the tag_options parameter appends
+// options to every tag of a given key.
+type OptionsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"-" uri:"id"`
+	Keyword *string                `protobuf:"bytes,2,opt,name=keyword,proto3,oneof" json:"-" query:"keyword,omitempty"`
+	Page    *int32                 `protobuf:"varint,3,opt,name=page,proto3,oneof" json:"-" query:"page,omitempty"`
+	// Not optional, so only configured options apply.
+	PageSize int32   `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"-" query:"page_size,omitempty"`
+	TraceId  *string `protobuf:"bytes,5,opt,name=trace_id,json=traceId,proto3,oneof" json:"-" header:"Trace-Id,omitempty"`
+	// Manual tags still win over generated options.
+	Sort          *string `protobuf:"bytes,6,opt,name=sort,proto3,oneof" json:"-" query:"sort"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OptionsRequest) Reset() {
+	*x = OptionsRequest{}
+	mi := &file_options_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptionsRequest) ProtoMessage() {}
+
+func (x *OptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_options_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptionsRequest.ProtoReflect.Descriptor instead.
+func (*OptionsRequest) Descriptor() ([]byte, []int) {
+	return file_options_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OptionsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OptionsRequest) GetKeyword() string {
+	if x != nil && x.Keyword != nil {
+		return *x.Keyword
+	}
+	return ""
+}
+
+func (x *OptionsRequest) GetPage() int32 {
+	if x != nil && x.Page != nil {
+		return *x.Page
+	}
+	return 0
+}
+
+func (x *OptionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *OptionsRequest) GetTraceId() string {
+	if x != nil && x.TraceId != nil {
+		return *x.TraceId
+	}
+	return ""
+}
+
+func (x *OptionsRequest) GetSort() string {
+	if x != nil && x.Sort != nil {
+		return *x.Sort
+	}
+	return ""
+}
+
+var File_options_proto protoreflect.FileDescriptor
+
+const file_options_proto_rawDesc = "" +
+	"\n" +
+	"\roptions.proto\x12\x13testdata.options.v1\x1a\x1csphere/binding/binding.proto\"\x85\x02\n" +
+	"\x0eOptionsRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xc0\x9d\xa6\x89\x04\x02R\x02id\x12\x1d\n" +
+	"\akeyword\x18\x02 \x01(\tH\x00R\akeyword\x88\x01\x01\x12\x17\n" +
+	"\x04page\x18\x03 \x01(\x05H\x01R\x04page\x88\x01\x01\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12&\n" +
+	"\btrace_id\x18\x05 \x01(\tB\x06\xc0\x9d\xa6\x89\x04\x05H\x02R\atraceId\x88\x01\x01\x12+\n" +
+	"\x04sort\x18\x06 \x01(\tB\x12ʝ\xa6\x89\x04\fquery:\"sort\"H\x03R\x04sort\x88\x01\x01:\x06\xa0\x9c\xa6\x89\x04\x01B\n" +
+	"\n" +
+	"\b_keywordB\a\n" +
+	"\x05_pageB\v\n" +
+	"\t_trace_idB\a\n" +
+	"\x05_sortBbZ`github.com/go-sphere/protoc-gen-sphere-binding/generate/binding/testdata/gen/optionsv1;optionsv1b\x06proto3"
+
+var (
+	file_options_proto_rawDescOnce sync.Once
+	file_options_proto_rawDescData []byte
+)
+
+func file_options_proto_rawDescGZIP() []byte {
+	file_options_proto_rawDescOnce.Do(func() {
+		file_options_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_options_proto_rawDesc), len(file_options_proto_rawDesc)))
+	})
+	return file_options_proto_rawDescData
+}
+
+var file_options_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_options_proto_goTypes = []any{
+	(*OptionsRequest)(nil), // 0: testdata.options.v1.OptionsRequest
+}
+var file_options_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_options_proto_init() }
+func file_options_proto_init() {
+	if File_options_proto != nil {
+		return
+	}
+	file_options_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_options_proto_rawDesc), len(file_options_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_options_proto_goTypes,
+		DependencyIndexes: file_options_proto_depIdxs,
+		MessageInfos:      file_options_proto_msgTypes,
+	}.Build()
+	File_options_proto = out.File
+	file_options_proto_goTypes = nil
+	file_options_proto_depIdxs = nil
+}
@@ -33,7 +33,12 @@ type TagsRequest struct {
 	// Field level auto_tags replaces the message default for this field.
 	Email string `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty" form:"email"`
 	// Location plus auto_tags combine: uri tag + the inherited validate tag.
-	Id            string `protobuf:"bytes,4,opt,name=id,proto3" json:"-" uri:"id" validate:"id"`
+	Id string `protobuf:"bytes,4,opt,name=id,proto3" json:"-" uri:"id" validate:"id"`
+	// An entry starting with '-' removes tag keys: the generated json tag and
+	// the inherited validate tag are dropped.
+	InternalNote string `protobuf:"bytes,5,opt,name=internal_note,json=internalNote,proto3"`
+	// A manual tag without a name adds its options to the generated tag.
+	Page          int32 `protobuf:"varint,6,opt,name=page,proto3" json:"-" query:"page,default=1" validate:"page"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -96,6 +101,20 @@ func (x *TagsRequest) GetId() string {
 	return ""
 }
 
+func (x *TagsRequest) GetInternalNote() string {
+	if x != nil {
+		return x.InternalNote
+	}
+	return ""
+}
+
+func (x *TagsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
 type TagsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Ok            string                 `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
@@ -145,13 +164,15 @@ var File_tags_proto protoreflect.FileDescriptor
 const file_tags_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
-	"tags.proto\x12\x10testdata.tags.v1\x1a\x1csphere/binding/binding.proto\"\xb3\x01\n" +
+	"tags.proto\x12\x10testdata.tags.v1\x1a\x1csphere/binding/binding.proto\"\xa3\x02\n" +
 	"\vTagsRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12F\n" +
 	"\bnickname\x18\x02 \x01(\tB*ʝ\xa6\x89\x04\vjson:\"nick\"ʝ\xa6\x89\x04\x13validate:\"required\"R\bnickname\x12 \n" +
 	"\x05email\x18\x03 \x01(\tB\n" +
 	"ҝ\xa6\x89\x04\x04formR\x05email\x12\x16\n" +
-	"\x02id\x18\x04 \x01(\tB\x06\xc0\x9d\xa6\x89\x04\x02R\x02id:\x0e\xaa\x9c\xa6\x89\x04\bvalidate\"\x1e\n" +
+	"\x02id\x18\x04 \x01(\tB\x06\xc0\x9d\xa6\x89\x04\x02R\x02id\x12:\n" +
+	"\rinternal_note\x18\x05 \x01(\tB\x15ʝ\xa6\x89\x04\x0f-json -validateR\finternalNote\x122\n" +
+	"\x04page\x18\x06 \x01(\x05B\x1e\xc0\x9d\xa6\x89\x04\x01ʝ\xa6\x89\x04\x12query:\",default=1\"R\x04page:\x0e\xaa\x9c\xa6\x89\x04\bvalidate\"\x1e\n" +
 	"\fTagsResponse\x12\x0e\n" +
 	"\x02ok\x18\x01 \x01(\tR\x02okB\\ZZgithub.com/go-sphere/protoc-gen-sphere-binding/generate/binding/testdata/gen/tagsv1;tagsv1b\x06proto3"
 
@@ -0,0 +1,332 @@
+package binding
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// comparisonRules maps the buf.validate rules shared by the numeric, bool,
+// string and enum rule messages to their go-playground validator tags.
+var comparisonRules = map[protoreflect.Name]string{
+	"const": "eq",
+	"lt":    "lt",
+	"lte":   "lte",
+	"gt":    "gt",
+	"gte":   "gte",
+}
+
+// lengthRules maps the string and bytes length rules. The validator counts
+// runes for strings and bytes for []byte, matching buf.validate.
+var lengthRules = map[protoreflect.Name]string{
+	"len":     "len",
+	"min_len": "min",
+	"max_len": "max",
+}
+
+var stringRules = map[protoreflect.Name]string{
+	"prefix":       "startswith",
+	"suffix":       "endswith",
+	"contains":     "contains",
+	"not_contains": "excludes",
+}
+
+// stringFormatRules maps the boolean well-known string formats.
+var stringFormatRules = map[protoreflect.Name]string{
+	"email":               "email",
+	"hostname":            "hostname_rfc1123",
+	"ip":                  "ip",
+	"ipv4":                "ipv4",
+	"ipv6":                "ipv6",
+	"uri":                 "uri",
+	"uuid":                "uuid",
+	"ip_with_prefixlen":   "cidr",
+	"ipv4_with_prefixlen": "cidrv4",
+	"ipv6_with_prefixlen": "cidrv6",
+	"host_and_port":       "hostname_port",
+}
+
+// ignoredRules do not constrain the value and never need a translation.
+var ignoredRules = map[protoreflect.Name]bool{
+	"example": true,
+	"strict":  true,
+}
+
+// UntranslatedRule describes a buf.validate rule on Field that has no
+// go-playground validator equivalent, e.g. "string.pattern".
+type UntranslatedRule struct {
+	Field protoreflect.FieldDescriptor
+	Rule  string
+}
+
+func (u UntranslatedRule) String() string {
+	return fmt.Sprintf("buf.validate rule %s on %s has no validator equivalent", u.Rule, u.Field.FullName())
+}
+
+// ReportValidationRules reports the buf.validate rules of file that cannot be
+// translated into the validation tags configured by Config.ValidationTags.
+// They are warnings on stderr, or an error in strict mode. It does nothing when
+// no validation tag is configured.
+func ReportValidationRules(file *protogen.File, config *Config) error {
+	if len(config.ValidationTags) == 0 {
+		return nil
+	}
+	var lines []string
+	for _, rule := range untranslatedRules(file.Messages) {
+		lines = append(lines, fmt.Sprintf("%s: %s", descriptorPosition(file, rule.Field), rule))
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	if config.Strict {
+		return fmt.Errorf("buf.validate rules could not be translated:\n  %s", strings.Join(lines, "\n  "))
+	}
+	for _, line := range lines {
+		_, _ = fmt.Fprintf(os.Stderr, "protoc-gen-sphere-binding: warning: %s\n", line)
+	}
+	return nil
+}
+
+func untranslatedRules(messages []*protogen.Message) []UntranslatedRule {
+	var untranslated []UntranslatedRule
+	for _, message := range messages {
+		if message.Desc.IsMapEntry() {
+			continue
+		}
+		for _, field := range message.Fields {
+			_, rules := translateFieldRules(field.Desc)
+			for _, rule := range rules {
+				untranslated = append(untranslated, UntranslatedRule{Field: field.Desc, Rule: rule})
+			}
+		}
+		untranslated = append(untranslated, untranslatedRules(message.Messages)...)
+	}
+	return untranslated
+}
+
+// translateFieldRules translates the (buf.validate.field) rules of field into a
+// go-playground validator tag value such as "required,max=64", as understood by
+// Gin's binding tag. It also returns the rules it had to drop.
+func translateFieldRules(field protoreflect.FieldDescriptor) (string, []string) {
+	options := field.Options()
+	if options == nil || !proto.HasExtension(options, validate.E_Field) {
+		return "", nil
+	}
+	rules := proto.GetExtension(options, validate.E_Field).(*validate.FieldRules)
+
+	t := &ruleTranslator{}
+	t.field(rules, "")
+	return strings.Join(t.tags, ","), t.untranslated
+}
+
+type ruleTranslator struct {
+	tags         []string
+	untranslated []string
+}
+
+func (t *ruleTranslator) field(rules *validate.FieldRules, path string) {
+	switch rules.GetIgnore() {
+	case validate.Ignore_IGNORE_ALWAYS:
+		return
+	case validate.Ignore_IGNORE_IF_ZERO_VALUE:
+		t.tags = append(t.tags, "omitempty")
+	}
+	if rules.GetRequired() {
+		t.tags = append(t.tags, "required")
+	}
+	if len(rules.GetCel()) > 0 {
+		t.untranslated = append(t.untranslated, path+"cel")
+	}
+
+	message := rules.ProtoReflect()
+	typeField := message.WhichOneof(message.Descriptor().Oneofs().ByName("type"))
+	if typeField == nil {
+		return
+	}
+	kind := typeField.Name()
+	typed := message.Get(typeField).Message()
+	switch kind {
+	case "repeated":
+		t.repeated(typed.Interface().(*validate.RepeatedRules), path)
+	case "map":
+		t.rules(typed, path+"map.", map[protoreflect.Name]string{"min_pairs": "min", "max_pairs": "max"})
+	case "string":
+		t.rules(typed, path+"string.", comparisonRules, lengthRules, stringRules, stringFormatRules)
+	case "bytes":
+		t.rules(typed, path+"bytes.", lengthRules)
+	case "duration", "timestamp", "any":
+		t.rules(typed, path+string(kind)+".")
+	default:
+		t.rules(typed, path+string(kind)+".", comparisonRules)
+	}
+}
+
+// repeated translates list rules, diving into the items last so the item tags
+// follow the validator's dive keyword.
+func (t *ruleTranslator) repeated(rules *validate.RepeatedRules, path string) {
+	list := proto.Clone(rules).ProtoReflect()
+	list.Clear(list.Descriptor().Fields().ByName("items"))
+	t.rules(list, path+"repeated.", map[protoreflect.Name]string{
+		"min_items": "min",
+		"max_items": "max",
+		"unique":    "unique",
+	})
+	if rules.HasItems() {
+		t.tags = append(t.tags, "dive")
+		t.field(rules.GetItems(), path+"repeated.items.")
+	}
+}
+
+// rules translates every populated field of a typed rule message with the
+// first of tagMaps that knows it. Fields are visited in declaration order so
+// the output is deterministic.
+func (t *ruleTranslator) rules(message protoreflect.Message, path string, tagMaps ...map[protoreflect.Name]string) {
+	exclusive := exclusiveRange(message)
+	for _, field := range exclusive {
+		t.untranslated = append(t.untranslated, path+string(field.Name()))
+	}
+	fields := message.Descriptor().Fields()
+	for i := range fields.Len() {
+		field := fields.Get(i)
+		if !message.Has(field) || ignoredRules[field.Name()] || slices.Contains(exclusive, field) {
+			continue
+		}
+		tag, ok := translateRule(field, message.Get(field), tagMaps)
+		if !ok {
+			t.untranslated = append(t.untranslated, path+string(field.Name()))
+			continue
+		}
+		if tag != "" {
+			t.tags = append(t.tags, tag)
+		}
+	}
+}
+
+// exclusiveRange returns the lower and upper bound rules of message when they
+// describe an exclusive range: buf.validate reads {gt: 10, lt: 5} as
+// x < 5 || x > 10, which validator tags cannot express, since they all must
+// hold. The bounds form an inclusive range when the upper bound is above the
+// lower one, or equal to it for gte and lte.
+func exclusiveRange(message protoreflect.Message) []protoreflect.FieldDescriptor {
+	fields := message.Descriptor().Fields()
+	var lower, upper protoreflect.FieldDescriptor
+	for _, name := range []protoreflect.Name{"gt", "gte"} {
+		if field := fields.ByName(name); field != nil && message.Has(field) {
+			lower = field
+		}
+	}
+	for _, name := range []protoreflect.Name{"lt", "lte"} {
+		if field := fields.ByName(name); field != nil && message.Has(field) {
+			upper = field
+		}
+	}
+	if lower == nil || upper == nil {
+		return nil
+	}
+	order, ok := compareBounds(lower, message.Get(lower), message.Get(upper))
+	if !ok {
+		return nil
+	}
+	if order < 0 || order == 0 && lower.Name() == "gte" && upper.Name() == "lte" {
+		return nil
+	}
+	return []protoreflect.FieldDescriptor{lower, upper}
+}
+
+// compareBounds compares two numeric rule values of the kind of field.
+func compareBounds(field protoreflect.FieldDescriptor, a, b protoreflect.Value) (int, bool) {
+	switch field.Kind() {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return cmp.Compare(a.Int(), b.Int()), true
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return cmp.Compare(a.Uint(), b.Uint()), true
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return cmp.Compare(a.Float(), b.Float()), true
+	default:
+		return 0, false
+	}
+}
+
+// translateRule returns the validator tag for one rule. Boolean rules other
+// than const are flags such as email or unique, which translate to nothing
+// when unset. "in" lists become oneof, which separates values by spaces, and
+// "not_in" lists a chain of ne, since every rule of a tag must hold. Both only
+// support string and integer kinds: the validator panics on floats in oneof,
+// and ne would compare them for exact equality.
+func translateRule(field protoreflect.FieldDescriptor, value protoreflect.Value, tagMaps []map[protoreflect.Name]string) (string, bool) {
+	name := field.Name()
+	if field.IsList() {
+		if name != "in" && name != "not_in" || len(tagMaps) == 0 {
+			return "", false
+		}
+		if kind := field.Kind(); kind == protoreflect.FloatKind || kind == protoreflect.DoubleKind {
+			return "", false
+		}
+		list := value.List()
+		params := make([]string, 0, list.Len())
+		for i := range list.Len() {
+			param, ok := ruleParam(field, list.Get(i))
+			if !ok || name == "in" && strings.Contains(param, " ") {
+				return "", false
+			}
+			params = append(params, param)
+		}
+		if name == "not_in" {
+			for i, param := range params {
+				params[i] = "ne=" + param
+			}
+			return strings.Join(params, ","), true
+		}
+		return "oneof=" + strings.Join(params, " "), true
+	}
+	for _, tags := range tagMaps {
+		tag, ok := tags[name]
+		if !ok {
+			continue
+		}
+		if field.Kind() == protoreflect.BoolKind && name != "const" {
+			if !value.Bool() {
+				return "", true
+			}
+			return tag, true
+		}
+		param, ok := ruleParam(field, value)
+		if !ok {
+			return "", false
+		}
+		return tag + "=" + param, true
+	}
+	return "", false
+}
+
+// ruleParam formats a rule value as a validator parameter. Bytes and message
+// values, and strings that would break the tag syntax, have no representation.
+func ruleParam(field protoreflect.FieldDescriptor, value protoreflect.Value) (string, bool) {
+	switch field.Kind() {
+	case protoreflect.BytesKind, protoreflect.MessageKind, protoreflect.GroupKind:
+		return "", false
+	case protoreflect.EnumKind:
+		return strconv.Itoa(int(value.Enum())), true
+	case protoreflect.FloatKind:
+		return strconv.FormatFloat(value.Float(), 'g', -1, 32), true
+	case protoreflect.DoubleKind:
+		return strconv.FormatFloat(value.Float(), 'g', -1, 64), true
+	case protoreflect.StringKind:
+		param := value.String()
+		if param == "" || strings.ContainsAny(param, ",|`\"") {
+			return "", false
+		}
+		return param, true
+	default:
+		return fmt.Sprint(value.Interface()), true
+	}
+}
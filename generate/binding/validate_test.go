@@ -0,0 +1,314 @@
+package binding
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// validateFileProto returns a file with a single ValidateRequest message whose
+// fields carry rules as their (buf.validate.field) option.
+func validateFileProto(typ descriptorpb.FieldDescriptorProto_Type, label descriptorpb.FieldDescriptorProto_Label, rules ...*validate.FieldRules) *descriptorpb.FileDescriptorProto {
+	message := &descriptorpb.DescriptorProto{Name: proto.String("ValidateRequest")}
+	for i, r := range rules {
+		opts := &descriptorpb.FieldOptions{}
+		proto.SetExtension(opts, validate.E_Field, r)
+		name := "field" + string(rune('a'+i))
+		message.Field = append(message.Field, &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			Number:   proto.Int32(int32(i + 1)),
+			Type:     typ.Enum(),
+			Label:    label.Enum(),
+			JsonName: proto.String(name),
+			Options:  opts,
+		})
+	}
+	return &descriptorpb.FileDescriptorProto{
+		Name:        proto.String("validate_test.proto"),
+		Package:     proto.String("api.v1"),
+		Syntax:      proto.String("proto3"),
+		Options:     &descriptorpb.FileOptions{GoPackage: proto.String("github.com/example/api/v1;apiv1")},
+		MessageType: []*descriptorpb.DescriptorProto{message},
+	}
+}
+
+func validateField(t *testing.T, typ descriptorpb.FieldDescriptorProto_Type, label descriptorpb.FieldDescriptorProto_Label, rules *validate.FieldRules) protoreflect.FieldDescriptor {
+	t.Helper()
+	file, err := protodesc.NewFile(validateFileProto(typ, label, rules), protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatalf("failed to build descriptor: %v", err)
+	}
+	return file.Messages().Get(0).Fields().Get(0)
+}
+
+func TestTranslateFieldRules(t *testing.T) {
+	const (
+		optional = descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+		repeated = descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+		str      = descriptorpb.FieldDescriptorProto_TYPE_STRING
+		int32T   = descriptorpb.FieldDescriptorProto_TYPE_INT32
+		double   = descriptorpb.FieldDescriptorProto_TYPE_DOUBLE
+	)
+	tests := []struct {
+		name             string
+		typ              descriptorpb.FieldDescriptorProto_Type
+		label            descriptorpb.FieldDescriptorProto_Label
+		rules            *validate.FieldRules
+		want             string
+		wantUntranslated []string
+	}{
+		{
+			name:  "required_string_length",
+			typ:   str,
+			label: optional,
+			rules: validate.FieldRules_builder{
+				Required: proto.Bool(true),
+				String:   validate.StringRules_builder{MinLen: proto.Uint64(1), MaxLen: proto.Uint64(64)}.Build(),
+			}.Build(),
+			want: "required,min=1,max=64",
+		},
+		{
+			name:  "string_formats",
+			typ:   str,
+			label: optional,
+			rules: validate.FieldRules_builder{
+				String: validate.StringRules_builder{Email: proto.Bool(true), Prefix: proto.String("user")}.Build(),
+			}.Build(),
+			want: "startswith=user,email",
+		},
+		{
+			name:  "string_in",
+			typ:   str,
+			label: optional,
+			rules: validate.FieldRules_builder{
+				String: validate.StringRules_builder{In: []string{"asc", "desc"}}.Build(),
+			}.Build(),
+			want: "oneof=asc desc",
+		},
+		{
+			name:  "untranslatable_string_rules",
+			typ:   str,
+			label: optional,
+			rules: validate.FieldRules_builder{
+				String: validate.StringRules_builder{
+					MaxLen:  proto.Uint64(8),
+					Pattern: proto.String("^[a-z]+$"),
+					In:      []string{"a b"},
+				}.Build(),
+			}.Build(),
+			want:             "max=8",
+			wantUntranslated: []string{"string.pattern", "string.in"},
+		},
+		{
+			name:  "int_range_and_in",
+			typ:   int32T,
+			label: optional,
+			rules: validate.FieldRules_builder{
+				Int32: validate.Int32Rules_builder{
+					Lte:   proto.Int32(100),
+					Gte:   proto.Int32(1),
+					In:    []int32{1, 10, 100},
+					NotIn: []int32{50},
+				}.Build(),
+			}.Build(),
+			want: "lte=100,gte=1,oneof=1 10 100,ne=50",
+		},
+		{
+			name:  "string_not_in",
+			typ:   str,
+			label: optional,
+			rules: validate.FieldRules_builder{
+				String: validate.StringRules_builder{NotIn: []string{"admin", "root user"}}.Build(),
+			}.Build(),
+			want: "ne=admin,ne=root user",
+		},
+		{
+			name:  "double_gt",
+			typ:   double,
+			label: optional,
+			rules: validate.FieldRules_builder{
+				Double: validate.DoubleRules_builder{Gt: proto.Float64(0.5)}.Build(),
+			}.Build(),
+			want: "gt=0.5",
+		},
+		{
+			name:  "int_exclusive_range",
+			typ:   int32T,
+			label: optional,
+			rules: validate.FieldRules_builder{
+				Int32: validate.Int32Rules_builder{Gt: proto.Int32(10), Lt: proto.Int32(5), In: []int32{1, 20}}.Build(),
+			}.Build(),
+			want:             "oneof=1 20",
+			wantUntranslated: []string{"int32.gt", "int32.lt"},
+		},
+		{
+			name:  "int_single_value_range",
+			typ:   int32T,
+			label: optional,
+			rules: validate.FieldRules_builder{
+				Int32: validate.Int32Rules_builder{Gte: proto.Int32(5), Lte: proto.Int32(5)}.Build(),
+			}.Build(),
+			want: "lte=5,gte=5",
+		},
+		{
+			name:  "double_exclusive_range",
+			typ:   double,
+			label: optional,
+			rules: validate.FieldRules_builder{
+				Double: validate.DoubleRules_builder{Gte: proto.Float64(1), Lt: proto.Float64(1)}.Build(),
+			}.Build(),
+			wantUntranslated: []string{"double.gte", "double.lt"},
+		},
+		{
+			name:  "double_in",
+			typ:   double,
+			label: optional,
+			rules: validate.FieldRules_builder{
+				Double: validate.DoubleRules_builder{Gt: proto.Float64(0), In: []float64{1.5, 2.5}, NotIn: []float64{3.5}}.Build(),
+			}.Build(),
+			want:             "gt=0",
+			wantUntranslated: []string{"double.in", "double.not_in"},
+		},
+		{
+			name:  "repeated_items",
+			typ:   str,
+			label: repeated,
+			rules: validate.FieldRules_builder{
+				Repeated: validate.RepeatedRules_builder{
+					MinItems: proto.Uint64(1),
+					Unique:   proto.Bool(true),
+					Items: validate.FieldRules_builder{
+						String: validate.StringRules_builder{MaxLen: proto.Uint64(36), Pattern: proto.String("^[a-z]+$")}.Build(),
+					}.Build(),
+				}.Build(),
+			}.Build(),
+			want:             "min=1,unique,dive,max=36",
+			wantUntranslated: []string{"repeated.items.string.pattern"},
+		},
+		{
+			name:  "ignore_if_zero",
+			typ:   str,
+			label: optional,
+			rules: validate.FieldRules_builder{
+				Ignore: validate.Ignore_IGNORE_IF_ZERO_VALUE.Enum(),
+				String: validate.StringRules_builder{Uri: proto.Bool(true)}.Build(),
+			}.Build(),
+			want: "omitempty,uri",
+		},
+		{
+			name:  "ignore_always",
+			typ:   str,
+			label: optional,
+			rules: validate.FieldRules_builder{
+				Ignore:   validate.Ignore_IGNORE_ALWAYS.Enum(),
+				Required: proto.Bool(true),
+			}.Build(),
+			want: "",
+		},
+		{
+			name:  "cel",
+			typ:   str,
+			label: optional,
+			rules: validate.FieldRules_builder{
+				Required: proto.Bool(true),
+				Cel:      []*validate.Rule{validate.Rule_builder{Expression: proto.String("this != 'x'")}.Build()},
+			}.Build(),
+			want:             "required",
+			wantUntranslated: []string{"cel"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := validateField(t, tt.typ, tt.label, tt.rules)
+			got, untranslated := translateFieldRules(field)
+			if got != tt.want {
+				t.Errorf("translateFieldRules = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(untranslated, tt.wantUntranslated) {
+				t.Errorf("untranslated = %q, want %q", untranslated, tt.wantUntranslated)
+			}
+		})
+	}
+}
+
+// validateTestFile builds a single-file plugin whose ValidateRequest message
+// has one string field per rules entry.
+func validateTestFile(t *testing.T, rules ...*validate.FieldRules) *protogen.File {
+	t.Helper()
+	fd := validateFileProto(descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, rules...)
+	req := &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{fd.GetName()},
+		ProtoFile:      []*descriptorpb.FileDescriptorProto{fd},
+	}
+	plugin, err := protogen.Options{}.New(req)
+	if err != nil {
+		t.Fatalf("failed to create plugin: %v", err)
+	}
+	return plugin.Files[0]
+}
+
+func TestExtractFile_ValidationTags(t *testing.T) {
+	file := validateTestFile(t,
+		validate.FieldRules_builder{
+			Required: proto.Bool(true),
+			String:   validate.StringRules_builder{MaxLen: proto.Uint64(64)}.Build(),
+		}.Build(),
+		validate.FieldRules_builder{
+			String: validate.StringRules_builder{Pattern: proto.String("^a$")}.Build(),
+		}.Build(),
+	)
+
+	config := DefaultConfig()
+	tags, err := extractFile(file, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := tags["ValidateRequest"]; len(got) != 0 {
+		t.Fatalf("expected no tags without validation_tags, got %v", got)
+	}
+
+	config.ValidationTags = []string{"binding", "validate"}
+	tags, err = extractFile(file, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := tags["ValidateRequest"]
+	if got, want := fields["Fielda"].String(), `binding:"required,max=64" validate:"required,max=64"`; got != want {
+		t.Fatalf("Fielda tags = %q, want %q", got, want)
+	}
+	if _, ok := fields["Fieldb"]; ok {
+		t.Fatalf("Fieldb has no translatable rule, got tags %q", fields["Fieldb"])
+	}
+}
+
+func TestReportValidationRules(t *testing.T) {
+	file := validateTestFile(t, validate.FieldRules_builder{
+		String: validate.StringRules_builder{Pattern: proto.String("^a$")}.Build(),
+	}.Build())
+
+	if err := ReportValidationRules(file, &Config{Strict: true}); err != nil {
+		t.Fatalf("expected no report without validation tags, got %v", err)
+	}
+
+	err := ReportValidationRules(file, &Config{Strict: true, ValidationTags: []string{"binding"}})
+	if err == nil {
+		t.Fatal("expected strict mode to fail on untranslated rules")
+	}
+	want := "validate_test.proto: buf.validate rule string.pattern on api.v1.ValidateRequest.fielda has no validator equivalent"
+	if !strings.Contains(err.Error(), want) {
+		t.Fatalf("error = %q, want it to contain %q", err, want)
+	}
+
+	if err := ReportValidationRules(file, &Config{ValidationTags: []string{"binding"}}); err != nil {
+		t.Fatalf("expected a warning only, got %v", err)
+	}
+}
@@ -5,6 +5,8 @@ package testutil
 
 import (
 	"os"
+	"os/exec"
+	"path/filepath"
 	"testing"
 
 	"google.golang.org/protobuf/compiler/protogen"
@@ -73,3 +75,35 @@ func FileToGenerate(t *testing.T, plugin *protogen.Plugin) *protogen.File {
 	t.Fatal("no file marked for generation")
 	return nil
 }
+
+// GoTest writes files, keyed by file name, into a new package under the
+// testdata directory of the current package and runs `go test` on it, so that
+// generated code is compiled, vetted and exercised together with the .pb.go it
+// extends. The package belongs to the enclosing module and uses its
+// dependencies. GoTest skips the test in -short mode or without a go command.
+func GoTest(t *testing.T, files map[string]string) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("compiling generated code is skipped in -short mode")
+	}
+	goCmd, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+
+	dir, err := os.MkdirTemp("testdata", "gotest-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out, err := exec.Command(goCmd, "test", "./"+filepath.ToSlash(dir)).CombinedOutput()
+	if err != nil {
+		t.Fatalf("go test on the generated code failed: %v\n%s", err, out)
+	}
+}
@@ -3,7 +3,9 @@ module github.com/go-sphere/protoc-gen-sphere-binding
 go 1.23.0
 
 require (
+	buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go v1.36.10-20250912141014-52f32327d4b0.1
 	github.com/fatih/structtag v1.2.0
 	github.com/go-sphere/binding v0.0.4
+	go.yaml.in/yaml/v3 v3.0.4
 	google.golang.org/protobuf v1.36.11
 )
@@ -3,6 +3,7 @@ package main
 import (
 	"flag"
 	"fmt"
+	"strings"
 
 	"github.com/go-sphere/protoc-gen-sphere-binding/generate/binding"
 	"google.golang.org/protobuf/compiler/protogen"
@@ -10,10 +11,35 @@ import (
 )
 
 var (
-	showVersion    = flag.Bool("version", false, "print the version and exit")
-	autoRemoveJson = flag.Bool("auto_remove_json", true, "automatically remove json tag if sphere binding location set")
-	bindingAliases = flag.String("binding_aliases", "", "example: query=form,uri=path,db=database. add additional tag aliases for any binding tag")
-	out            = flag.String("out", "api", "output directory for generated files")
+	showVersion        = flag.Bool("version", false, "print the version and exit")
+	autoRemoveJson     = flag.Bool("auto_remove_json", true, "automatically remove json tag if sphere binding location set")
+	bindingAliases     = flag.String("binding_aliases", "", "example: query=form,uri=path,db=database. add additional tag aliases for any binding tag")
+	out                = flag.String("out", "api", "output directory for generated files")
+	mode               = flag.String("mode", "rewrite", "rewrite: retag the .pb.go files under out in place. response: run protoc-gen-go and return the retagged files in the plugin response. combined: generate the .pb.go files in-process and return them retagged")
+	check              = flag.Bool("check", false, "report .pb.go files under out that are missing or have stale binding tags instead of rewriting them (mode=rewrite only)")
+	dryRun             = flag.Bool("dry_run", false, "emit a unified diff of the planned tag changes as <name>.binding.diff instead of rewriting the .pb.go files under out (mode=rewrite only)")
+	strict             = flag.Bool("strict", false, "fail when a binding tag matches no field in the generated Go structs")
+	tagNaming          = listVar("tag_naming", "example: camel or header=header_canonical. tag value naming strategy (proto, json, camel, kebab, header_canonical) for all or one binding location. repeatable")
+	tagOptions         = listVar("tag_options", "example: query=omitempty. option appended to every generated tag of the key. repeatable")
+	optionalOmitEmpty  = flag.Bool("optional_omitempty", false, "add omitempty to the binding tags of fields declared optional")
+	validationTags     = listVar("validation_tags", "example: binding. tag key that receives validator rules translated from buf.validate constraints. repeatable")
+	deprecatedFields   = flag.String("deprecated_fields", "tag", "tag: tag deprecated fields as usual. skip: leave them untagged except for manual tags. mark: also add a deprecated:\"true\" tag")
+	defaultLocation    = flag.String("default_location", "", "example: query. file-wide default binding location of the request messages of the file's service methods (query, uri, json, form, header, file, cookie)")
+	defaultAutoTags    = listVar("default_auto_tags", "example: db. file-wide default auto tag of every message. repeatable")
+	serviceAutoTags    = listVar("service_auto_tags", "example: validate. default auto tag of the request messages of the file's services. repeatable")
+	methodLocations    = listVar("method_locations", "example: GET=query. default binding location of the request messages of methods routed with the HTTP method. repeatable")
+	pruneTags          = flag.Bool("prune_tags", true, "remove tags added by an earlier run that the plugin no longer generates when rewriting .pb.go files")
+	customLocations    = listVar("custom_locations", "example: grpc_metadata:metadata. location name and the tag key it binds with, selected with a \"@name\" manual tag. repeatable")
+	manifest           = flag.String("manifest", "", "json: also emit a <name>.binding.json manifest listing the resolved location, auto tags and tags of every field")
+	explain            = flag.Bool("explain", false, "write to stderr, for every field, the declarations consulted for its location and auto tags and why it got its tags")
+	bindFuncs          = flag.Bool("bind_funcs", false, "also emit a <name>.binding.go declaring a Bind<Message>(ctx *gin.Context) function per request message that binds the locations it uses with gin and then validates it")
+	httpBinders        = flag.Bool("http_binders", false, "also emit a <name>.binding_http.go declaring a reflection-free BindHTTPRequest(r *http.Request, pathParams map[string]string) error method per message bound outside the JSON body")
+	enumUnmarshalers   = flag.Bool("enum_unmarshalers", false, "also emit a <name>.binding_enum.go declaring gin UnmarshalParam methods that accept value names on the enums of fields bound outside the JSON body")
+	rulesFile          = flag.String("rules_file", "", "YAML file of tagging rules matched by fully-qualified message and field name globs")
+	headerPrefix       = flag.String("header_prefix", "", "example: X-. prefix added to derived header tag values")
+	protocGenGo        = flag.String("protoc_gen_go", "protoc-gen-go", "protoc-gen-go binary used by mode=response")
+	inferURILocations  = flag.Bool("infer_uri_locations", false, "bind fields referenced by google.api.http path variables to the uri location")
+	inferHTTPLocations = flag.Bool("infer_http_locations", false, "infer uri, json and query locations from the google.api.http path and body")
 )
 
 func main() {
@@ -27,19 +53,165 @@ func main() {
 	}.Run(func(gen *protogen.Plugin) error {
 		gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
 
+		// check and dry_run inspect the .pb.go files under out, which only
+		// mode=rewrite reads.
+		if *mode != "rewrite" {
+			if *check {
+				return fmt.Errorf("check requires mode=rewrite, got mode=%q", *mode)
+			}
+			if *dryRun {
+				return fmt.Errorf("dry_run requires mode=rewrite, got mode=%q", *mode)
+			}
+		}
+
 		aliases, err := binding.ParseBindingAliases(*bindingAliases)
 		if err != nil {
 			return err
 		}
 
+		naming, err := binding.ParseTagNaming(tagNaming.String())
+		if err != nil {
+			return err
+		}
+
+		prefix, err := binding.ParseHeaderPrefix(*headerPrefix)
+		if err != nil {
+			return err
+		}
+
+		options, err := binding.ParseTagOptions(tagOptions.String())
+		if err != nil {
+			return err
+		}
+
+		validation, err := binding.ParseTagKeys(validationTags.String())
+		if err != nil {
+			return fmt.Errorf("invalid validation_tags: %w", err)
+		}
+
+		location, err := binding.ParseBindingLocation(*defaultLocation)
+		if err != nil {
+			return fmt.Errorf("invalid default_location: %w", err)
+		}
+
+		fileAutoTags, err := binding.ParseTagKeys(defaultAutoTags.String())
+		if err != nil {
+			return fmt.Errorf("invalid default_auto_tags: %w", err)
+		}
+
+		serviceTags, err := binding.ParseTagKeys(serviceAutoTags.String())
+		if err != nil {
+			return fmt.Errorf("invalid service_auto_tags: %w", err)
+		}
+
+		methods, err := binding.ParseMethodLocations(methodLocations.String())
+		if err != nil {
+			return err
+		}
+
+		deprecated, err := binding.ParseDeprecatedMode(*deprecatedFields)
+		if err != nil {
+			return err
+		}
+
+		custom, err := binding.ParseCustomLocations(customLocations.String())
+		if err != nil {
+			return err
+		}
+
+		manifestFormat, err := binding.ParseManifestFormat(*manifest)
+		if err != nil {
+			return err
+		}
+
+		var rules *binding.Rules
+		if *rulesFile != "" {
+			if rules, err = binding.LoadRules(*rulesFile); err != nil {
+				return err
+			}
+		}
+
+		config := &binding.Config{
+			AutoRemoveJson:     *autoRemoveJson,
+			BindingAliases:     aliases,
+			InferURILocations:  *inferURILocations,
+			InferHTTPLocations: *inferHTTPLocations,
+			ServiceFiles:       gen.Files,
+			Strict:             *strict,
+			TagNaming:          naming,
+			HeaderPrefix:       prefix,
+			TagOptions:         options,
+			OptionalOmitEmpty:  *optionalOmitEmpty,
+			ValidationTags:     validation,
+			DeprecatedFields:   deprecated,
+			DefaultLocation:    location,
+			DefaultAutoTags:    fileAutoTags,
+			ServiceAutoTags:    serviceTags,
+			MethodLocations:    methods,
+			Rules:              rules,
+			CustomLocations:    custom,
+			PruneTags:          *pruneTags,
+		}
+
+		for _, f := range gen.Files {
+			if !f.Generate {
+				continue
+			}
+			if vErr := binding.ReportValidationRules(f, config); vErr != nil {
+				return vErr
+			}
+			if *explain {
+				if eErr := binding.ExplainFile(f, config); eErr != nil {
+					return eErr
+				}
+			}
+			if *bindFuncs {
+				if bErr := binding.GenerateBindFuncs(gen, f, config); bErr != nil {
+					return bErr
+				}
+			}
+			if *httpBinders {
+				if hErr := binding.GenerateHTTPBinders(gen, f, config); hErr != nil {
+					return hErr
+				}
+			}
+			if manifestFormat != "" {
+				if mErr := binding.GenerateManifest(gen, f, config); mErr != nil {
+					return mErr
+				}
+			}
+		}
+
+		if *enumUnmarshalers {
+			if eErr := binding.GenerateEnumUnmarshalers(gen, config); eErr != nil {
+				return eErr
+			}
+		}
+
+		switch *mode {
+		case "rewrite":
+			if *check {
+				return checkFiles(gen, config)
+			}
+		case "response":
+			return binding.GenerateResponse(gen, *protocGenGo, config)
+		case "combined":
+			return binding.GenerateCombined(gen, config)
+		default:
+			return fmt.Errorf("unknown mode %q: want \"rewrite\", \"response\" or \"combined\"", *mode)
+		}
+
 		for _, f := range gen.Files {
 			if !f.Generate {
 				continue
 			}
-			bErr := binding.GenerateFile(f, *out, &binding.Config{
-				AutoRemoveJson: *autoRemoveJson,
-				BindingAliases: aliases,
-			})
+			if *dryRun {
+				if dErr := binding.GenerateDiff(gen, f, *out, config); dErr != nil {
+					return dErr
+				}
+				continue
+			}
+			bErr := binding.GenerateFile(f, *out, config)
 			if bErr != nil {
 				return bErr
 			}
@@ -47,3 +219,46 @@ func main() {
 		return nil
 	})
 }
+
+// checkFiles verifies that every generated .pb.go file under out already carries
+// the binding tags, returning an error that lists each stale field.
+func checkFiles(gen *protogen.Plugin, config *binding.Config) error {
+	var stale []string
+	for _, f := range gen.Files {
+		if !f.Generate {
+			continue
+		}
+		changes, err := binding.CheckFile(f, *out, config)
+		if err != nil {
+			return err
+		}
+		for _, change := range changes {
+			stale = append(stale, fmt.Sprintf("%s: %s", f.Desc.Path(), change))
+		}
+	}
+	if len(stale) > 0 {
+		return fmt.Errorf("binding tags are out of date:\n  %s", strings.Join(stale, "\n  "))
+	}
+	return nil
+}
+
+// listVar defines a repeatable list parameter with the given name and usage.
+func listVar(name, usage string) *listFlag {
+	l := &listFlag{}
+	flag.Var(l, name, usage)
+	return l
+}
+
+// listFlag collects every occurrence of a repeatable parameter. protoc splits
+// the plugin parameter string on commas before it reaches the flag package, so
+// list values are passed as repeated parameters instead.
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}